@@ -0,0 +1,28 @@
+package engine // import "github.com/docker/docker/engine"
+
+import "encoding/json"
+
+// AggregateProgress redirects each child's emitted events onto the parent
+// job's Stdout, tagging each with the child's name, so a parent that spawns
+// several children (such as pulling the layers of an image) can present a
+// single merged progress stream instead of the caller polling each child.
+func (job *Job) AggregateProgress(children ...*Job) {
+	for _, child := range children {
+		child := child
+		child.Stdout = &lineWriter{fn: func(line string) {
+			var e Event
+			if err := json.Unmarshal([]byte(line), &e); err != nil {
+				return
+			}
+			if e.Data == nil {
+				e.Data = make(map[string]interface{})
+			}
+			e.Data["child"] = child.Name
+			b, err := json.Marshal(e)
+			if err != nil {
+				return
+			}
+			job.Stdout.Write(append(b, '\n'))
+		}}
+	}
+}
@@ -0,0 +1,43 @@
+package engine // import "github.com/docker/docker/engine"
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestAggregateProgress(t *testing.T) {
+	eng := New()
+	eng.Register("child", func(job *Job) string {
+		job.Progress(1, 1)
+		return StatusOK
+	})
+
+	parent := eng.Job("parent")
+	out := new(bytes.Buffer)
+	parent.Stdout = out
+
+	childA := eng.Job("child")
+	childB := eng.Job("child")
+	parent.AggregateProgress(childA, childB)
+
+	if err := childA.Run(); err != nil {
+		t.Fatal(err)
+	}
+	if err := childB.Run(); err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 aggregated events, got %d: %v", len(lines), lines)
+	}
+	var e Event
+	if err := json.Unmarshal([]byte(lines[0]), &e); err != nil {
+		t.Fatal(err)
+	}
+	if e.Data["child"] != "child" {
+		t.Fatalf("expected event tagged with child name, got %v", e.Data)
+	}
+}
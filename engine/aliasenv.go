@@ -0,0 +1,13 @@
+package engine // import "github.com/docker/docker/engine"
+
+// AliasEnv registers alias as an alternate spelling of canonical: Getenv
+// for jobs created by this engine checks canonical first and, if unset,
+// falls back to alias. This lets handlers check a single canonical name
+// instead of every spelling a given setting is known by across tools (e.g.
+// "HTTP_PROXY" vs "http_proxy").
+func (eng *Engine) AliasEnv(canonical, alias string) {
+	if eng.envAliases == nil {
+		eng.envAliases = make(map[string][]string)
+	}
+	eng.envAliases[canonical] = append(eng.envAliases[canonical], alias)
+}
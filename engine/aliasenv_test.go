@@ -0,0 +1,15 @@
+package engine // import "github.com/docker/docker/engine"
+
+import "testing"
+
+func TestAliasEnv(t *testing.T) {
+	eng := New()
+	eng.AliasEnv("HTTP_PROXY", "http_proxy")
+
+	job := eng.Job("test")
+	job.Setenv("http_proxy", "http://proxy:8080")
+
+	if got := job.Getenv("HTTP_PROXY"); got != "http://proxy:8080" {
+		t.Fatalf("expected canonical Getenv to find the alias's value, got %q", got)
+	}
+}
@@ -0,0 +1,18 @@
+package engine // import "github.com/docker/docker/engine"
+
+import "testing"
+
+// AssertNoStderr captures the job's Stderr, runs it, and fails t if
+// anything was written to it during the run. This catches handlers that
+// emit unexpected warnings, which tests asserting only on Stdout or the
+// return status would otherwise miss.
+func (job *Job) AssertNoStderr(t testing.TB) {
+	stderr := &CapturedOutput{}
+	job.Stderr = stderr
+	if err := job.Run(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := stderr.String(); got != "" {
+		t.Fatalf("expected no stderr output, got %q", got)
+	}
+}
@@ -0,0 +1,41 @@
+package engine // import "github.com/docker/docker/engine"
+
+import (
+	"fmt"
+	"testing"
+)
+
+// fakeTB records Fatalf calls instead of actually failing the test that
+// constructed it, so AssertNoStderr's failure path can be exercised
+// without taking down the real test run.
+type fakeTB struct {
+	testing.TB
+	failed  bool
+	message string
+}
+
+func (f *fakeTB) Fatalf(format string, args ...interface{}) {
+	f.failed = true
+	f.message = fmt.Sprintf(format, args...)
+}
+
+func TestAssertNoStderrPassesWhenClean(t *testing.T) {
+	eng := New()
+	eng.Register("clean", func(*Job) string { return StatusOK })
+	eng.Job("clean").AssertNoStderr(t)
+}
+
+func TestAssertNoStderrFailsWhenDirty(t *testing.T) {
+	eng := New()
+	eng.Register("dirty", func(j *Job) string {
+		j.Stderr.Write([]byte("uh oh"))
+		return StatusOK
+	})
+
+	fake := &fakeTB{}
+	eng.Job("dirty").AssertNoStderr(fake)
+
+	if !fake.failed {
+		t.Fatal("expected AssertNoStderr to report a failure for stderr output")
+	}
+}
@@ -0,0 +1,25 @@
+package engine // import "github.com/docker/docker/engine"
+
+import "context"
+
+// SetBaseContext installs ctx as the context every job run on this Engine
+// derives from, via Run as well as RunContext, so cancelling ctx acts as a
+// master kill switch for every in-flight job.
+func (eng *Engine) SetBaseContext(ctx context.Context) {
+	eng.baseCtx = ctx
+}
+
+// mergeContext returns a context cancelled when either a or b is
+// cancelled, for combining a per-run context with the engine's base
+// context without either one needing to know about the other.
+func mergeContext(a, b context.Context) context.Context {
+	ctx, cancel := context.WithCancel(a)
+	go func() {
+		select {
+		case <-b.Done():
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	return ctx
+}
@@ -0,0 +1,35 @@
+package engine // import "github.com/docker/docker/engine"
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestSetBaseContextCancelsRunningJob(t *testing.T) {
+	eng := New()
+	ctx, cancel := context.WithCancel(context.Background())
+	eng.SetBaseContext(ctx)
+
+	started := make(chan struct{})
+	eng.Register("hangs", func(*Job) string {
+		close(started)
+		select {}
+	})
+
+	job := eng.Job("hangs")
+	errCh := make(chan error, 1)
+	go func() { errCh <- job.Run() }()
+
+	<-started
+	cancel()
+
+	select {
+	case err := <-errCh:
+		if job.Status() != StatusCancelled {
+			t.Fatalf("expected status %q, got %q (err=%v)", StatusCancelled, job.Status(), err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected Run to observe base context cancellation")
+	}
+}
@@ -0,0 +1,38 @@
+package engine // import "github.com/docker/docker/engine"
+
+// trackRunning registers job as currently executing, so CancelByLabel can
+// find it.
+func (eng *Engine) trackRunning(job *Job) {
+	eng.runningMu.Lock()
+	defer eng.runningMu.Unlock()
+	if eng.running == nil {
+		eng.running = make(map[*Job]struct{})
+	}
+	eng.running[job] = struct{}{}
+}
+
+// untrackRunning removes job from the set of currently executing jobs.
+func (eng *Engine) untrackRunning(job *Job) {
+	eng.runningMu.Lock()
+	defer eng.runningMu.Unlock()
+	delete(eng.running, job)
+}
+
+// CancelByLabel cancels every currently-running job whose label key is set
+// to value, via the same mechanism as CancelWithGrace, and returns how many
+// jobs matched. Jobs that were never made cancelable with Cancelable are
+// counted but otherwise unaffected.
+func (eng *Engine) CancelByLabel(key, value string) int {
+	eng.runningMu.Lock()
+	defer eng.runningMu.Unlock()
+	var n int
+	for job := range eng.running {
+		if got, ok := job.Label(key); ok && got == value {
+			n++
+			if job.cancelFunc != nil {
+				job.cancelFunc()
+			}
+		}
+	}
+	return n
+}
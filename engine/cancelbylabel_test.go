@@ -0,0 +1,52 @@
+package engine // import "github.com/docker/docker/engine"
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCancelByLabelAffectsOnlyMatchingJobs(t *testing.T) {
+	eng := New()
+	started := make(chan struct{}, 2)
+	canceled := make(chan string, 2)
+	eng.Register("hangs", func(job *Job) string {
+		ctx, _ := job.Cancelable()
+		started <- struct{}{}
+		<-ctx.Done()
+		canceled <- job.Name
+		return StatusCancelled
+	})
+
+	target := eng.Job("hangs")
+	target.SetLabel("group", "a")
+	other := eng.Job("hangs")
+	other.SetLabel("group", "b")
+
+	go target.Run()
+	go other.Run()
+	<-started
+	<-started
+
+	if n := eng.CancelByLabel("group", "a"); n != 1 {
+		t.Fatalf("expected 1 job cancelled, got %d", n)
+	}
+
+	select {
+	case <-canceled:
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected the labeled job to be cancelled")
+	}
+
+	select {
+	case <-canceled:
+		t.Fatal("expected the non-matching job to remain running")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	eng.CancelByLabel("group", "b")
+	select {
+	case <-canceled:
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected the other job to be cancellable afterward")
+	}
+}
@@ -0,0 +1,63 @@
+package engine // import "github.com/docker/docker/engine"
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// Cancelable returns a context and its CancelFunc for running this job via
+// RunContext, and records the CancelFunc so that CancelWithGrace can use
+// it. Call RunContext with the returned context to enable graceful
+// cancellation.
+func (job *Job) Cancelable() (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(context.Background())
+	job.cancelFunc = cancel
+	return ctx, cancel
+}
+
+// CancelWithGrace cancels the job's run context (as set up by Cancelable),
+// giving a cooperative handler grace to notice and return. If the job
+// hasn't finished once grace elapses, CancelWithGrace escalates by closing
+// any of Stdin/Stdout/Stderr the job owns (as registered via OwnStream), to
+// unblock I/O an uncooperative handler is stuck on. Caller-supplied streams
+// are left alone, for the same ownership reason OwnStream documents: the
+// job has no business closing something it didn't open. This mirrors
+// SIGTERM-then-SIGKILL semantics.
+func (job *Job) CancelWithGrace(grace time.Duration) {
+	if job.cancelFunc != nil {
+		job.cancelFunc()
+	}
+	go func() {
+		time.Sleep(grace)
+		if job.State() != Finished {
+			job.closeOwnedMainStreams()
+		}
+	}()
+}
+
+// closeOwnedMainStreams closes whichever of Stdin/Stdout/Stderr were
+// registered via OwnStream, ignoring any that are caller-supplied.
+func (job *Job) closeOwnedMainStreams() {
+	isOwned := func(v interface{}) bool {
+		c, ok := v.(io.Closer)
+		if !ok {
+			return false
+		}
+		for _, owned := range job.ownedStreams {
+			if owned == c {
+				return true
+			}
+		}
+		return false
+	}
+	if isOwned(job.Stdin) {
+		job.Stdin.(io.Closer).Close()
+	}
+	if isOwned(job.Stdout) {
+		job.Stdout.(io.Closer).Close()
+	}
+	if isOwned(job.Stderr) {
+		job.Stderr.(io.Closer).Close()
+	}
+}
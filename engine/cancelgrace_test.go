@@ -0,0 +1,88 @@
+package engine // import "github.com/docker/docker/engine"
+
+import (
+	"io"
+	"testing"
+	"time"
+)
+
+func TestCancelableCancelsContext(t *testing.T) {
+	eng := New()
+	job := eng.Job("test")
+	ctx, _ := job.Cancelable()
+
+	job.CancelWithGrace(time.Hour)
+
+	select {
+	case <-ctx.Done():
+	case <-time.After(time.Second):
+		t.Fatal("expected CancelWithGrace to cancel the job's context")
+	}
+}
+
+func TestCancelWithGraceEscalatesAfterGrace(t *testing.T) {
+	eng := New()
+	pr, pw := io.Pipe()
+	defer pw.Close()
+
+	job := eng.Job("test")
+	job.Stdin = pr
+	job.OwnStream(pr)
+	job.Cancelable()
+
+	job.CancelWithGrace(10 * time.Millisecond)
+
+	buf := make([]byte, 1)
+	_, err := pr.Read(buf)
+	if err == nil {
+		t.Fatal("expected Stdin to be closed once grace elapses")
+	}
+}
+
+type closeRecorder struct {
+	closed bool
+}
+
+func (c *closeRecorder) Read([]byte) (int, error) {
+	select {}
+}
+
+func (c *closeRecorder) Close() error {
+	c.closed = true
+	return nil
+}
+
+func TestCancelWithGraceLeavesCallerSuppliedStreamsOpen(t *testing.T) {
+	eng := New()
+	stream := &closeRecorder{}
+
+	job := eng.Job("test")
+	job.Stdin = stream
+	job.Cancelable()
+
+	job.CancelWithGrace(10 * time.Millisecond)
+	time.Sleep(50 * time.Millisecond)
+
+	if stream.closed {
+		t.Fatal("expected the caller-supplied stream not to be closed")
+	}
+}
+
+func TestCancelWithGraceDoesNotEscalateIfFinished(t *testing.T) {
+	eng := New()
+	stream := &closeRecorder{}
+
+	job := eng.Job("test")
+	job.Stdin = stream
+	job.OwnStream(stream)
+	job.Cancelable()
+	job.state = int32(Finished)
+
+	job.CancelWithGrace(10 * time.Millisecond)
+	time.Sleep(50 * time.Millisecond)
+
+	// Stdin should still be open since the job had already finished.
+	if stream.closed {
+		t.Fatal("expected the owned stream not to be closed for a finished job")
+	}
+}
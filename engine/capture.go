@@ -0,0 +1,35 @@
+package engine // import "github.com/docker/docker/engine"
+
+import "sync"
+
+// CapturedOutput is an io.Writer safe for concurrent use, so that a handler
+// abandoned by RunSafely after a timeout can keep writing to it without
+// racing a caller reading the output it already produced.
+type CapturedOutput struct {
+	mu  sync.Mutex
+	buf []byte
+}
+
+// Write appends p to the captured output.
+func (c *CapturedOutput) Write(p []byte) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.buf = append(c.buf, p...)
+	return len(p), nil
+}
+
+// String returns the output captured so far.
+func (c *CapturedOutput) String() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return string(c.buf)
+}
+
+// CaptureStdout installs a CapturedOutput as the job's Stdout and returns
+// it, so the output written by the handler (including anything written
+// before a RunSafely timeout abandons it) can be read safely afterward.
+func (job *Job) CaptureStdout() *CapturedOutput {
+	out := &CapturedOutput{}
+	job.Stdout = out
+	return out
+}
@@ -0,0 +1,43 @@
+package engine // import "github.com/docker/docker/engine"
+
+import "sync"
+
+// TaggedLine is one line captured by CaptureTagged, tagged with which
+// stream it came from.
+type TaggedLine struct {
+	Stream string // "stdout" or "stderr"
+	Text   string
+}
+
+// TaggedBuffer records lines written to a job's Stdout and Stderr in
+// arrival order, each tagged with its source stream. Unlike one buffer
+// shared by both streams, which just interleaves raw writes, it preserves line boundaries and lets
+// a caller tell stdout output apart from stderr output afterward.
+type TaggedBuffer struct {
+	mu    sync.Mutex
+	lines []TaggedLine
+}
+
+func (t *TaggedBuffer) append(stream, line string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.lines = append(t.lines, TaggedLine{Stream: stream, Text: line})
+}
+
+// Lines returns the lines captured so far, in arrival order.
+func (t *TaggedBuffer) Lines() []TaggedLine {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]TaggedLine(nil), t.lines...)
+}
+
+// CaptureTagged installs line-splitting writers as the job's Stdout and
+// Stderr that record every line into the returned TaggedBuffer, tagged
+// with its source stream, so a handler mixing the two can be debugged
+// without losing track of which stream said what.
+func (job *Job) CaptureTagged() *TaggedBuffer {
+	buf := &TaggedBuffer{}
+	job.Stdout = &lineWriter{fn: func(line string) { buf.append("stdout", line) }}
+	job.Stderr = &lineWriter{fn: func(line string) { buf.append("stderr", line) }}
+	return buf
+}
@@ -0,0 +1,28 @@
+package engine // import "github.com/docker/docker/engine"
+
+import "testing"
+
+func TestCaptureTagged(t *testing.T) {
+	eng := New()
+	job := eng.Job("test")
+	buf := job.CaptureTagged()
+
+	job.Stdout.Write([]byte("out1\n"))
+	job.Stderr.Write([]byte("err1\n"))
+	job.Stdout.Write([]byte("out2\n"))
+
+	lines := buf.Lines()
+	want := []TaggedLine{
+		{Stream: "stdout", Text: "out1"},
+		{Stream: "stderr", Text: "err1"},
+		{Stream: "stdout", Text: "out2"},
+	}
+	if len(lines) != len(want) {
+		t.Fatalf("expected %d lines, got %d: %v", len(want), len(lines), lines)
+	}
+	for i, line := range lines {
+		if line != want[i] {
+			t.Fatalf("line %d: expected %+v, got %+v", i, want[i], line)
+		}
+	}
+}
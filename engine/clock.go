@@ -0,0 +1,32 @@
+package engine // import "github.com/docker/docker/engine"
+
+import "time"
+
+// Clock abstracts time for testing duration- and timeout-based behavior
+// deterministically. Step and StartHeartbeat use it instead of calling
+// time.Now/time.After directly.
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+// realClock is the default Clock, backed by the time package.
+type realClock struct{}
+
+func (realClock) Now() time.Time                         { return time.Now() }
+func (realClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+// SetClock installs clock as the job's time source for Step and
+// StartHeartbeat. The default is a real clock; tests can inject a fake one
+// to trigger timeout/duration/heartbeat behavior without real sleeping.
+func (job *Job) SetClock(clock Clock) {
+	job.clock = clock
+}
+
+// getClock returns the job's clock, defaulting to the real one.
+func (job *Job) getClock() Clock {
+	if job.clock != nil {
+		return job.clock
+	}
+	return realClock{}
+}
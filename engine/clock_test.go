@@ -0,0 +1,52 @@
+package engine // import "github.com/docker/docker/engine"
+
+import (
+	"testing"
+	"time"
+)
+
+// fakeClock gives tests full control over when After's channel fires,
+// instead of waiting on real time to pass.
+type fakeClock struct {
+	now chan time.Time
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: make(chan time.Time, 1)}
+}
+
+func (c *fakeClock) Now() time.Time { return time.Time{} }
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	return c.now
+}
+
+// fire simulates the clock reaching a deadline.
+func (c *fakeClock) fire() {
+	c.now <- time.Time{}
+}
+
+func TestSetClockTriggersTimeoutWithoutSleeping(t *testing.T) {
+	eng := New()
+	job := eng.Job("test")
+	clock := newFakeClock()
+	job.SetClock(clock)
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- job.Step("slow", time.Hour, func() error {
+			select {} // never returns on its own
+		})
+	}()
+
+	clock.fire()
+
+	select {
+	case err := <-errCh:
+		if err == nil {
+			t.Fatal("expected a timeout error")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected Step to return promptly once the fake clock fires")
+	}
+}
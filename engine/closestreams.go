@@ -0,0 +1,30 @@
+package engine // import "github.com/docker/docker/engine"
+
+import "io"
+
+// OwnStream registers c as a stream the job owns (for example, a file or
+// gzip writer it opened itself), rather than one supplied by the caller
+// (such as an http.ResponseWriter). Owned streams are closed after Run when
+// SetCloseStreams(true) is in effect; caller-supplied streams are never
+// closed automatically, since the job has no business closing something it
+// didn't open.
+func (job *Job) OwnStream(c io.Closer) {
+	job.ownedStreams = append(job.ownedStreams, c)
+}
+
+// SetCloseStreams controls whether Run closes the job's owned streams (as
+// registered via OwnStream) once it returns. It defaults to false.
+func (job *Job) SetCloseStreams(close bool) {
+	job.closeStreamsAfterRun = close
+}
+
+// closeOwnedStreams closes every stream registered via OwnStream, if
+// SetCloseStreams(true) is in effect.
+func (job *Job) closeOwnedStreams() {
+	if !job.closeStreamsAfterRun {
+		return
+	}
+	for _, c := range job.ownedStreams {
+		c.Close()
+	}
+}
@@ -0,0 +1,33 @@
+package engine // import "github.com/docker/docker/engine"
+
+import "testing"
+
+type closeTracker struct {
+	closed bool
+}
+
+func (c *closeTracker) Close() error {
+	c.closed = true
+	return nil
+}
+
+func TestSetCloseStreamsClosesOnlyOwned(t *testing.T) {
+	eng := New()
+	owned := &closeTracker{}
+	borrowed := &closeTracker{}
+
+	eng.Register("noop", func(*Job) string { return StatusOK })
+	job := eng.Job("noop")
+	job.OwnStream(owned)
+	job.SetCloseStreams(true)
+
+	if err := job.Run(); err != nil {
+		t.Fatal(err)
+	}
+	if !owned.closed {
+		t.Fatal("expected owned stream to be closed after Run")
+	}
+	if borrowed.closed {
+		t.Fatal("expected borrowed stream to remain untouched")
+	}
+}
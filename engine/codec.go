@@ -0,0 +1,54 @@
+package engine // import "github.com/docker/docker/engine"
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// Codec serializes and deserializes an environment map, so transports
+// other than JSON (gob, msgpack, a compact binary format) can be plugged
+// into EncodeEnvCodec/DecodeEnvCodec without changing their signatures.
+type Codec interface {
+	Marshal(map[string]string) ([]byte, error)
+	Unmarshal([]byte, *map[string]string) error
+}
+
+// JSONCodec is the default Codec, backed by encoding/json.
+type JSONCodec struct{}
+
+// Marshal encodes env as JSON.
+func (JSONCodec) Marshal(env map[string]string) ([]byte, error) {
+	return json.Marshal(env)
+}
+
+// Unmarshal decodes JSON into env.
+func (JSONCodec) Unmarshal(b []byte, env *map[string]string) error {
+	return json.Unmarshal(b, env)
+}
+
+// EncodeEnvCodec serializes the job's environment to w using c.
+func (job *Job) EncodeEnvCodec(c Codec, w io.Writer) error {
+	b, err := c.Marshal(job.Environ())
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(b)
+	return err
+}
+
+// DecodeEnvCodec reads from r and sets the decoded entries as job env using
+// c.
+func (job *Job) DecodeEnvCodec(c Codec, r io.Reader) error {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	var env map[string]string
+	if err := c.Unmarshal(b, &env); err != nil {
+		return err
+	}
+	for key, value := range env {
+		job.Setenv(key, value)
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package engine // import "github.com/docker/docker/engine"
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// upperCodec is a trivial custom Codec for testing: it upper-cases values
+// on the wire and lower-cases them back on decode.
+type upperCodec struct{}
+
+func (upperCodec) Marshal(env map[string]string) ([]byte, error) {
+	var parts []string
+	for k, v := range env {
+		parts = append(parts, k+"="+strings.ToUpper(v))
+	}
+	return []byte(strings.Join(parts, ";")), nil
+}
+
+func (upperCodec) Unmarshal(b []byte, env *map[string]string) error {
+	m := make(map[string]string)
+	for _, part := range strings.Split(string(b), ";") {
+		if part == "" {
+			continue
+		}
+		k, v, _ := splitKV(part)
+		m[k] = strings.ToLower(v)
+	}
+	*env = m
+	return nil
+}
+
+func TestEnvCodecRoundTrip(t *testing.T) {
+	eng := New()
+	job := eng.Job("test")
+	job.Setenv("NAME", "alice")
+
+	buf := new(bytes.Buffer)
+	if err := job.EncodeEnvCodec(upperCodec{}, buf); err != nil {
+		t.Fatal(err)
+	}
+
+	other := eng.Job("test2")
+	if err := other.DecodeEnvCodec(upperCodec{}, buf); err != nil {
+		t.Fatal(err)
+	}
+	if got := other.Getenv("NAME"); got != "alice" {
+		t.Fatalf("expected NAME=alice after round-trip, got %q", got)
+	}
+}
@@ -0,0 +1,19 @@
+package engine // import "github.com/docker/docker/engine"
+
+// SetResult records a key/value pair in the job's result namespace, for
+// handlers that produce output beyond a bare status. Results are returned
+// to the caller by RunCollect.
+func (job *Job) SetResult(key, value string) {
+	if job.results == nil {
+		job.results = make(map[string]string)
+	}
+	job.results[key] = value
+}
+
+// RunCollect runs the job and returns its status string together with
+// whatever results the handler populated via SetResult. This is the
+// one-call path for handlers that produce output as well as a status.
+func (job *Job) RunCollect() (status string, results map[string]string, err error) {
+	err = job.Run()
+	return job.Status(), job.results, err
+}
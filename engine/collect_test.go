@@ -0,0 +1,26 @@
+package engine // import "github.com/docker/docker/engine"
+
+import "testing"
+
+func TestRunCollect(t *testing.T) {
+	eng := New()
+	if err := eng.Register("produce", func(job *Job) string {
+		job.SetResult("id", "abc123")
+		job.SetResult("size", "42")
+		return StatusOK
+	}); err != nil {
+		t.Fatal(err)
+	}
+	job := eng.Job("produce")
+
+	status, results, err := job.RunCollect()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status != StatusOK {
+		t.Fatalf("expected status %q, got %q", StatusOK, status)
+	}
+	if results["id"] != "abc123" || results["size"] != "42" {
+		t.Fatalf("unexpected results: %#v", results)
+	}
+}
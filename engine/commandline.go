@@ -0,0 +1,43 @@
+package engine // import "github.com/docker/docker/engine"
+
+import (
+	"sort"
+	"strings"
+)
+
+// CommandLine renders the job as a shell-like invocation: "KEY=VALUE ... name
+// arg1 arg2", with values and arguments containing whitespace quoted,
+// secret-looking env keys (per secretKeyPattern) masked, and any value or
+// argument matching a pattern registered via AddRedactPattern masked as
+// well. It is meant for logging and for reproducing a job's invocation by
+// eye, not for actual execution.
+func (job *Job) CommandLine() string {
+	var parts []string
+	keys := make([]string, 0, len(job.env.Map()))
+	env := job.env.Map()
+	for key := range env {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		value := env[key]
+		if secretKeyPattern.MatchString(key) {
+			value = "***"
+		}
+		parts = append(parts, key+"="+shellQuote(job.redact(value)))
+	}
+	parts = append(parts, job.Name)
+	for _, arg := range job.Args {
+		parts = append(parts, shellQuote(job.redact(arg)))
+	}
+	return strings.Join(parts, " ")
+}
+
+// shellQuote wraps s in single quotes if it contains whitespace, leaving it
+// bare otherwise.
+func shellQuote(s string) string {
+	if !strings.ContainsAny(s, " \t\n") {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
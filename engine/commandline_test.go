@@ -0,0 +1,21 @@
+package engine // import "github.com/docker/docker/engine"
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCommandLineQuotesAndMasks(t *testing.T) {
+	eng := New()
+	job := eng.Job("run", "hello world")
+	job.Setenv("NAME", "alice")
+	job.Setenv("TOKEN", "sekrit value")
+
+	cmd := job.CommandLine()
+	if strings.Contains(cmd, "sekrit") {
+		t.Fatalf("expected TOKEN to be masked, got %q", cmd)
+	}
+	if !strings.Contains(cmd, "'hello world'") {
+		t.Fatalf("expected arg with space to be quoted, got %q", cmd)
+	}
+}
@@ -0,0 +1,106 @@
+package engine // import "github.com/docker/docker/engine"
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// DecodeError wraps a JSON syntax error encountered by DecodeEnv with the
+// byte offset it occurred at and a snippet of the surrounding input, so
+// the caller can locate the problem in a large payload without re-parsing
+// it themselves.
+type DecodeError struct {
+	Offset  int64
+	Snippet string
+	Err     error
+}
+
+// Error implements the error interface.
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("decode env: %v at offset %d (near %q)", e.Err, e.Offset, e.Snippet)
+}
+
+// Unwrap returns the underlying error, so errors.Is/As see through to it.
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// snippetAround returns up to 20 bytes on either side of offset in data.
+func snippetAround(data []byte, offset int64) string {
+	start, end := offset-20, offset+20
+	if start < 0 {
+		start = 0
+	}
+	if end > int64(len(data)) {
+		end = int64(len(data))
+	}
+	return string(data[start:end])
+}
+
+// DecodeEnv reads a JSON object of string values from src and merges them
+// into the job's environment. A malformed payload is reported as a
+// *DecodeError, giving the byte offset and surrounding input instead of
+// just the opaque underlying json error.
+func (job *Job) DecodeEnv(src io.Reader) error {
+	data, err := io.ReadAll(src)
+	if err != nil {
+		return err
+	}
+	var m map[string]string
+	if err := json.Unmarshal(data, &m); err != nil {
+		var syn *json.SyntaxError
+		if errors.As(err, &syn) {
+			return &DecodeError{Offset: syn.Offset, Snippet: snippetAround(data, syn.Offset), Err: err}
+		}
+		return err
+	}
+	for k, v := range m {
+		job.Setenv(k, v)
+	}
+	return nil
+}
+
+// DecodeEnvLimit behaves like DecodeEnv, but caps src at maxBytes via
+// io.LimitReader, returning a clear error if the limit is reached before a
+// complete object is parsed. This protects the engine from oversized
+// payloads coming from an untrusted client over the API.
+func (job *Job) DecodeEnvLimit(src io.Reader, maxBytes int64) error {
+	limited := &io.LimitedReader{R: src, N: maxBytes}
+	var m map[string]string
+	if err := json.NewDecoder(limited).Decode(&m); err != nil {
+		if limited.N <= 0 {
+			return fmt.Errorf("env payload exceeds the %d byte limit", maxBytes)
+		}
+		return err
+	}
+	for k, v := range m {
+		job.Setenv(k, v)
+	}
+	return nil
+}
+
+// DecodeEnvStrict behaves like DecodeEnv, but additionally verifies that
+// src contains no data after the JSON object. json.Decoder.Decode stops at
+// the first value and silently ignores anything that follows, which can
+// hide concatenated or corrupted payloads; DecodeEnvStrict rejects them.
+func (job *Job) DecodeEnvStrict(src io.Reader) error {
+	data, err := io.ReadAll(src)
+	if err != nil {
+		return err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	var m map[string]string
+	if err := dec.Decode(&m); err != nil {
+		return err
+	}
+	if rest := bytes.TrimSpace(data[dec.InputOffset():]); len(rest) > 0 {
+		return fmt.Errorf("unexpected trailing data after env object")
+	}
+	for k, v := range m {
+		job.Setenv(k, v)
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package engine // import "github.com/docker/docker/engine"
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestDecodeEnvStrictRejectsTrailingData(t *testing.T) {
+	eng := New()
+	job := eng.Job("test")
+
+	src := `{"NAME":"value"}}junk`
+	if err := job.DecodeEnvStrict(strings.NewReader(src)); err == nil {
+		t.Fatal("expected an error for trailing data")
+	}
+}
+
+func TestDecodeEnvStrictAcceptsCleanObject(t *testing.T) {
+	eng := New()
+	job := eng.Job("test")
+
+	src := `{"NAME":"value"}`
+	if err := job.DecodeEnvStrict(strings.NewReader(src)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if job.Getenv("NAME") != "value" {
+		t.Fatalf("expected NAME to be set, got %q", job.Getenv("NAME"))
+	}
+}
+
+func TestDecodeEnvMalformedReturnsDecodeError(t *testing.T) {
+	eng := New()
+	job := eng.Job("test")
+
+	src := `{"NAME": "value", "BROKEN": }`
+	err := job.DecodeEnv(strings.NewReader(src))
+	if err == nil {
+		t.Fatal("expected an error for malformed JSON")
+	}
+	var decodeErr *DecodeError
+	if !errors.As(err, &decodeErr) {
+		t.Fatalf("expected a *DecodeError, got %T: %v", err, err)
+	}
+	if decodeErr.Offset == 0 {
+		t.Fatal("expected a nonzero offset")
+	}
+}
+
+func TestDecodeEnvLimitRejectsOversizedPayload(t *testing.T) {
+	eng := New()
+	job := eng.Job("test")
+
+	src := `{"NAME":"` + strings.Repeat("x", 1000) + `"}`
+	if err := job.DecodeEnvLimit(strings.NewReader(src), 16); err == nil {
+		t.Fatal("expected an error for an oversized payload")
+	}
+}
+
+func TestDecodeEnvLimitAcceptsSmallPayload(t *testing.T) {
+	eng := New()
+	job := eng.Job("test")
+
+	src := `{"NAME":"value"}`
+	if err := job.DecodeEnvLimit(strings.NewReader(src), 1024); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if job.Getenv("NAME") != "value" {
+		t.Fatalf("expected NAME to be set, got %q", job.Getenv("NAME"))
+	}
+}
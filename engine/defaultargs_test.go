@@ -0,0 +1,21 @@
+package engine // import "github.com/docker/docker/engine"
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSetDefaultArgs(t *testing.T) {
+	eng := New()
+	eng.SetDefaultArgs("deploy", "--env", "prod")
+
+	withDefaults := eng.Job("deploy")
+	if !reflect.DeepEqual(withDefaults.Args, []string{"--env", "prod"}) {
+		t.Fatalf("expected default args, got %v", withDefaults.Args)
+	}
+
+	withOwn := eng.Job("deploy", "--env", "staging")
+	if !reflect.DeepEqual(withOwn.Args, []string{"--env", "staging"}) {
+		t.Fatalf("expected caller-supplied args to win, got %v", withOwn.Args)
+	}
+}
@@ -0,0 +1,22 @@
+package engine // import "github.com/docker/docker/engine"
+
+import "os"
+
+// DiffOS compares the job's environment against the OS environment, to
+// help debug subprocess environment surprises. added holds keys the job
+// sets that aren't present in the OS environment at all; overridden holds
+// keys present in both but set to a different value by the job.
+func (job *Job) DiffOS() (added, overridden map[string]string) {
+	added = make(map[string]string)
+	overridden = make(map[string]string)
+	for key, value := range job.Environ() {
+		osValue, present := os.LookupEnv(key)
+		switch {
+		case !present:
+			added[key] = value
+		case osValue != value:
+			overridden[key] = value
+		}
+	}
+	return added, overridden
+}
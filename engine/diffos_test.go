@@ -0,0 +1,24 @@
+package engine // import "github.com/docker/docker/engine"
+
+import (
+	"os"
+	"testing"
+)
+
+func TestDiffOS(t *testing.T) {
+	os.Setenv("ENGINE_DIFFOS_TEST", "host-value")
+	defer os.Unsetenv("ENGINE_DIFFOS_TEST")
+
+	eng := New()
+	job := eng.Job("test")
+	job.Setenv("ENGINE_DIFFOS_TEST", "job-value")
+	job.Setenv("ENGINE_DIFFOS_NEW", "new-value")
+
+	added, overridden := job.DiffOS()
+	if added["ENGINE_DIFFOS_NEW"] != "new-value" {
+		t.Fatalf("expected ENGINE_DIFFOS_NEW to be reported as added, got %v", added)
+	}
+	if overridden["ENGINE_DIFFOS_TEST"] != "job-value" {
+		t.Fatalf("expected ENGINE_DIFFOS_TEST to be reported as overridden, got %v", overridden)
+	}
+}
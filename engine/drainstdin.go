@@ -0,0 +1,14 @@
+package engine // import "github.com/docker/docker/engine"
+
+import "io"
+
+// DrainStdin copies the job's Stdin to io.Discard and returns the number of
+// bytes drained. It is a safe no-op returning (0, nil) when Stdin is nil.
+// Handlers that don't need the request body but must still unblock a
+// client writing and waiting on it should call this before returning.
+func (job *Job) DrainStdin() (int64, error) {
+	if job.Stdin == nil {
+		return 0, nil
+	}
+	return io.Copy(io.Discard, job.Stdin)
+}
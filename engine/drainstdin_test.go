@@ -0,0 +1,30 @@
+package engine // import "github.com/docker/docker/engine"
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDrainStdin(t *testing.T) {
+	eng := New()
+	job := eng.Job("test")
+	job.Stdin = strings.NewReader("hello world")
+
+	n, err := job.DrainStdin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 11 {
+		t.Fatalf("expected 11 bytes drained, got %d", n)
+	}
+}
+
+func TestDrainStdinNil(t *testing.T) {
+	eng := New()
+	job := eng.Job("test")
+
+	n, err := job.DrainStdin()
+	if err != nil || n != 0 {
+		t.Fatalf("expected (0, nil) for nil Stdin, got (%d, %v)", n, err)
+	}
+}
@@ -0,0 +1,19 @@
+package engine // import "github.com/docker/docker/engine"
+
+// SetDryRun toggles dry-run mode for jobs created by this engine. While
+// enabled, Run records each job's CommandLine into the report returned by
+// DryRunReport instead of invoking its handler, and reports success.
+// Enabling it resets any previously accumulated report.
+func (eng *Engine) SetDryRun(enable bool) {
+	eng.dryRun = enable
+	if enable {
+		eng.dryRunReport = nil
+	}
+}
+
+// DryRunReport returns the ordered list of command lines recorded by Run
+// while dry-run mode was enabled, such as for previewing a --dry-run batch
+// or graph before any handler actually runs.
+func (eng *Engine) DryRunReport() []string {
+	return eng.dryRunReport
+}
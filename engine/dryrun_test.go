@@ -0,0 +1,30 @@
+package engine // import "github.com/docker/docker/engine"
+
+import "testing"
+
+func TestDryRunReport(t *testing.T) {
+	eng := New()
+	invoked := false
+	eng.Register("build", func(*Job) string {
+		invoked = true
+		return StatusOK
+	})
+	eng.SetDryRun(true)
+
+	first := eng.Job("build", "a")
+	second := eng.Job("build", "b")
+	if err := first.Run(); err != nil {
+		t.Fatal(err)
+	}
+	if err := second.Run(); err != nil {
+		t.Fatal(err)
+	}
+
+	if invoked {
+		t.Fatal("expected the handler not to be invoked in dry-run mode")
+	}
+	report := eng.DryRunReport()
+	if len(report) != 2 {
+		t.Fatalf("expected a two-entry report, got %d: %v", len(report), report)
+	}
+}
@@ -0,0 +1,133 @@
+// Package engine implements a minimal job-execution framework: named,
+// handler-backed operations (Job) with a string-keyed environment (Env),
+// dispatched through an Engine's handler registry.
+package engine // import "github.com/docker/docker/engine"
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// Engine dispatches named Jobs to registered Handlers, resolved through a
+// Registry. By default each Engine owns a private Registry; use
+// NewEngineWithRegistry to share one across several Engines.
+type Engine struct {
+	registry      *Registry
+	successStatus string
+	// overrides, when non-nil, are consulted before the Registry. Set via
+	// WithOverrides to scope handler substitutions to a derived Engine.
+	overrides map[string]Handler
+	// envResolver, when set via SetEnvResolver, post-processes every value
+	// returned by Getenv for jobs created by this Engine.
+	envResolver      func(job *Job, key, raw string) string
+	envOpStats       envOpCounters
+	envAliases       map[string][]string
+	lastGraphResults map[*Job]error
+	// maxSpawnDepth, when non-zero, is the deepest chain of Spawn calls
+	// this Engine allows. Set via SetMaxSpawnDepth.
+	maxSpawnDepth int
+	// dryRun, when enabled via SetDryRun, makes Run record the job's
+	// CommandLine into dryRunReport instead of invoking its handler.
+	dryRun       bool
+	dryRunReport []string
+	subMu        sync.Mutex
+	subscribers  []chan Event
+	inFlight     sync.WaitGroup
+	defaultArgs  map[string][]string
+	// baseCtx, when set via SetBaseContext, is derived from by every job
+	// run on this Engine, so cancelling it cancels all in-flight jobs.
+	baseCtx context.Context
+	// runningMu guards running, the set of jobs currently executing on
+	// this Engine, consulted by CancelByLabel.
+	runningMu sync.Mutex
+	running   map[*Job]struct{}
+	// slowJobMu guards slowJobWatchers, the callbacks registered via
+	// OnSlowJob.
+	slowJobMu       sync.Mutex
+	slowJobWatchers []slowJobWatcher
+	// cacheMu guards cache, populated once EnableCache is called.
+	cacheMu      sync.Mutex
+	cacheEnabled bool
+	cache        map[string]cachedJobResult
+	// defaultFlags holds engine-wide feature flag defaults consulted by
+	// Job.FeatureEnabled when the job's own env doesn't set the flag.
+	defaultFlags map[string]bool
+	// outputMu guards lastOutputs, populated once EnableOutputRetention
+	// is called.
+	outputMu      sync.Mutex
+	retainOutputs bool
+	lastOutputs   map[string][]byte
+	// Logger is where Logf writes engine-level messages, not tied to any
+	// particular job (startup, handler registration, etc). It defaults to
+	// io.Discard.
+	Logger io.Writer
+}
+
+// New returns a new Engine with its own, private Registry.
+func New() *Engine {
+	return &Engine{
+		registry: NewRegistry(),
+		Logger:   io.Discard,
+	}
+}
+
+// Logf writes a formatted message to the engine's Logger, for messages not
+// tied to a specific job.
+func (eng *Engine) Logf(format string, args ...interface{}) {
+	fmt.Fprintf(eng.Logger, format, args...)
+}
+
+// NewEngineWithRegistry returns a new Engine that resolves handlers through
+// r. Multiple Engines may share the same Registry.
+func NewEngineWithRegistry(r *Registry) *Engine {
+	return &Engine{
+		registry: r,
+		Logger:   io.Discard,
+	}
+}
+
+// SetSuccessStatus configures the status string that Run and Succeeded
+// treat as success for jobs created by this engine, instead of the default
+// StatusOK ("0"). This is useful when wrapping handlers written against a
+// different convention, such as "OK" or "".
+func (eng *Engine) SetSuccessStatus(status string) {
+	eng.successStatus = status
+}
+
+// Register associates name with handler in the engine's Registry, so that
+// jobs created with that name will invoke it. It returns an error if a
+// handler is already registered for name.
+func (eng *Engine) Register(name string, handler Handler) error {
+	return eng.registry.Register(name, handler)
+}
+
+// Job creates a new Job bound to this Engine with the given name and
+// arguments. If a handler is registered for name, it is attached; otherwise
+// the Job will fail to run with StatusNotFound.
+func (eng *Engine) Job(name string, args ...string) *Job {
+	if len(args) == 0 {
+		args = eng.defaultArgs[name]
+	}
+	job := &Job{
+		Eng:    eng,
+		Name:   name,
+		Args:   args,
+		Stdout: io.Discard,
+		Stderr: io.Discard,
+	}
+	job.handler, _ = eng.lookup(name)
+	return job
+}
+
+// SetDefaultArgs configures args to be used for jobs created with Job(name)
+// whenever the caller doesn't supply its own arguments, reducing
+// boilerplate at call sites that always pass the same leading args (such as
+// a default subcommand).
+func (eng *Engine) SetDefaultArgs(name string, args ...string) {
+	if eng.defaultArgs == nil {
+		eng.defaultArgs = make(map[string][]string)
+	}
+	eng.defaultArgs[name] = args
+}
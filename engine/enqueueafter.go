@@ -0,0 +1,26 @@
+package engine // import "github.com/docker/docker/engine"
+
+import "time"
+
+// ScheduledJob is a handle on a job enqueued via EnqueueAfter, letting the
+// caller cancel it before it starts.
+type ScheduledJob struct {
+	timer *time.Timer
+}
+
+// Cancel prevents the scheduled job from running, if it hasn't started
+// yet. It reports whether the cancellation was in time.
+func (s *ScheduledJob) Cancel() bool {
+	return s.timer.Stop()
+}
+
+// EnqueueAfter runs job in its own goroutine after delay elapses, without
+// blocking the caller, and returns a handle that can cancel it beforehand.
+// This supports simple deferred tasks without standing up a separate
+// scheduler.
+func (eng *Engine) EnqueueAfter(job *Job, delay time.Duration) *ScheduledJob {
+	timer := time.AfterFunc(delay, func() {
+		job.Run()
+	})
+	return &ScheduledJob{timer: timer}
+}
@@ -0,0 +1,43 @@
+package engine // import "github.com/docker/docker/engine"
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEnqueueAfterRunsAfterDelay(t *testing.T) {
+	eng := New()
+	ran := make(chan struct{})
+	eng.Register("later", func(*Job) string {
+		close(ran)
+		return StatusOK
+	})
+
+	eng.EnqueueAfter(eng.Job("later"), 10*time.Millisecond)
+
+	select {
+	case <-ran:
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected the job to run after the delay")
+	}
+}
+
+func TestEnqueueAfterCancelPreventsRun(t *testing.T) {
+	eng := New()
+	ran := make(chan struct{})
+	eng.Register("later", func(*Job) string {
+		close(ran)
+		return StatusOK
+	})
+
+	scheduled := eng.EnqueueAfter(eng.Job("later"), 20*time.Millisecond)
+	if !scheduled.Cancel() {
+		t.Fatal("expected Cancel to succeed before the delay elapses")
+	}
+
+	select {
+	case <-ran:
+		t.Fatal("expected the cancelled job not to run")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
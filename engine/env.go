@@ -0,0 +1,137 @@
+package engine // import "github.com/docker/docker/engine"
+
+import (
+	"encoding/json"
+	"strconv"
+)
+
+// Env is an ordered list of "key=value" pairs, used to carry a Job's
+// configuration and environment. Unlike a map, repeated Set calls with the
+// same key keep the key's original position while updating its value.
+type Env []string
+
+// Get returns the value associated with key, or "" if key is not set.
+func (env *Env) Get(key string) string {
+	for _, kv := range *env {
+		if k, v, ok := splitKV(kv); ok && k == key {
+			return v
+		}
+	}
+	return ""
+}
+
+// Exists reports whether key is present in env.
+func (env *Env) Exists(key string) bool {
+	for _, kv := range *env {
+		if k, _, ok := splitKV(kv); ok && k == key {
+			return true
+		}
+	}
+	return false
+}
+
+// GetBool returns the boolean value of key. Any value accepted by
+// strconv.ParseBool is recognized; anything else (including an absent key)
+// is treated as false.
+func (env *Env) GetBool(key string) bool {
+	b, _ := strconv.ParseBool(env.Get(key))
+	return b
+}
+
+// SetBool sets key to the string representation of value.
+func (env *Env) SetBool(key string, value bool) {
+	env.Set(key, strconv.FormatBool(value))
+}
+
+// GetInt returns the integer value of key, or 0 if key is absent or not a
+// valid integer.
+func (env *Env) GetInt(key string) int {
+	return int(env.GetInt64(key))
+}
+
+// SetInt sets key to the string representation of value.
+func (env *Env) SetInt(key string, value int) {
+	env.SetInt64(key, int64(value))
+}
+
+// GetInt64 returns the integer value of key, or 0 if key is absent or not a
+// valid integer.
+func (env *Env) GetInt64(key string) int64 {
+	n, err := strconv.ParseInt(env.Get(key), 10, 64)
+	if err != nil {
+		return 0
+	}
+	return n
+}
+
+// SetInt64 sets key to the string representation of value.
+func (env *Env) SetInt64(key string, value int64) {
+	env.Set(key, strconv.FormatInt(value, 10))
+}
+
+// GetList returns the value of key decoded as a JSON array of strings, as
+// produced by SetList. A missing or invalid value yields a nil slice.
+func (env *Env) GetList(key string) []string {
+	raw := env.Get(key)
+	if raw == "" {
+		return nil
+	}
+	var l []string
+	if err := json.Unmarshal([]byte(raw), &l); err != nil {
+		return nil
+	}
+	return l
+}
+
+// SetList stores value as a JSON-encoded array under key.
+func (env *Env) SetList(key string, value []string) {
+	b, err := json.Marshal(value)
+	if err != nil {
+		panic(err) // Cannot happen: value is a []string
+	}
+	env.Set(key, string(b))
+}
+
+// Set assigns value to key, updating it in place if key already exists and
+// appending it otherwise.
+func (env *Env) Set(key, value string) {
+	for i, kv := range *env {
+		if k, _, ok := splitKV(kv); ok && k == key {
+			(*env)[i] = key + "=" + value
+			return
+		}
+	}
+	*env = append(*env, key+"="+value)
+}
+
+// Unset removes key from env, if present.
+func (env *Env) Unset(key string) {
+	for i, kv := range *env {
+		if k, _, ok := splitKV(kv); ok && k == key {
+			*env = append((*env)[:i], (*env)[i+1:]...)
+			return
+		}
+	}
+}
+
+// Map returns env as a map[string]string. Ordering information is lost.
+func (env *Env) Map() map[string]string {
+	m := make(map[string]string, len(*env))
+	for _, kv := range *env {
+		if k, v, ok := splitKV(kv); ok {
+			m[k] = v
+		}
+	}
+	return m
+}
+
+// splitKV splits a "key=value" string into its two parts. ok is false if
+// kv does not contain a '='.
+func splitKV(kv string) (key, value string, ok bool) {
+	for i := 0; i < len(kv); i++ {
+		if kv[i] == '=' {
+			return kv[:i], kv[i+1:], true
+		}
+	}
+	return "", "", false
+}
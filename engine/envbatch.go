@@ -0,0 +1,44 @@
+package engine // import "github.com/docker/docker/engine"
+
+// EnvBatch accumulates Set/Unset operations for UpdateEnv, which applies
+// them to the job only if the caller's function returns nil.
+type EnvBatch struct {
+	sets   map[string]string
+	unsets map[string]bool
+}
+
+// Set stages key to be set to value if the batch is committed.
+func (b *EnvBatch) Set(key, value string) {
+	if b.sets == nil {
+		b.sets = make(map[string]string)
+	}
+	delete(b.unsets, key)
+	b.sets[key] = value
+}
+
+// Unset stages key to be removed if the batch is committed.
+func (b *EnvBatch) Unset(key string) {
+	if b.unsets == nil {
+		b.unsets = make(map[string]bool)
+	}
+	delete(b.sets, key)
+	b.unsets[key] = true
+}
+
+// UpdateEnv calls fn with a fresh EnvBatch, applying its staged Set/Unset
+// operations to the job only if fn returns nil. This gives transactional
+// semantics over the environment: a validation failure midway through a
+// multi-key update leaves the job's env untouched.
+func (job *Job) UpdateEnv(fn func(b *EnvBatch) error) error {
+	batch := &EnvBatch{}
+	if err := fn(batch); err != nil {
+		return err
+	}
+	for key, value := range batch.sets {
+		job.Setenv(key, value)
+	}
+	for key := range batch.unsets {
+		job.env.Unset(job.namespaced(key))
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package engine // import "github.com/docker/docker/engine"
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestUpdateEnvDiscardsOnError(t *testing.T) {
+	eng := New()
+	job := eng.Job("test")
+	job.Setenv("EXISTING", "1")
+
+	err := job.UpdateEnv(func(b *EnvBatch) error {
+		b.Set("NEW", "2")
+		b.Unset("EXISTING")
+		return errors.New("validation failed")
+	})
+	if err == nil {
+		t.Fatal("expected error to propagate")
+	}
+	if job.Getenv("NEW") != "" {
+		t.Fatal("expected staged Set to be discarded")
+	}
+	if job.Getenv("EXISTING") != "1" {
+		t.Fatal("expected staged Unset to be discarded")
+	}
+}
+
+func TestUpdateEnvCommitsOnSuccess(t *testing.T) {
+	eng := New()
+	job := eng.Job("test")
+	job.Setenv("EXISTING", "1")
+
+	err := job.UpdateEnv(func(b *EnvBatch) error {
+		b.Set("NEW", "2")
+		b.Unset("EXISTING")
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if job.Getenv("NEW") != "2" {
+		t.Fatalf("expected NEW=2, got %q", job.Getenv("NEW"))
+	}
+	if job.HasEnv("EXISTING") {
+		t.Fatal("expected EXISTING to be unset")
+	}
+}
@@ -0,0 +1,79 @@
+package engine // import "github.com/docker/docker/engine"
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// WriteEnvBinary writes the job's environment to w as a sequence of
+// uvarint-length-prefixed key/value pairs: uvarint(len(key)) key
+// uvarint(len(value)) value, repeated for each entry. Unlike a line-based
+// KEY=VALUE dump or the JSON wire format, this survives values containing newlines or NUL
+// bytes, and avoids JSON parsing cost on hot IPC paths.
+func (job *Job) WriteEnvBinary(w io.Writer) error {
+	buf := make([]byte, binary.MaxVarintLen64)
+	writeField := func(s string) error {
+		n := binary.PutUvarint(buf, uint64(len(s)))
+		if _, err := w.Write(buf[:n]); err != nil {
+			return err
+		}
+		_, err := io.WriteString(w, s)
+		return err
+	}
+	for _, kv := range job.env {
+		key, value, ok := splitKV(kv)
+		if !ok {
+			continue
+		}
+		if err := writeField(key); err != nil {
+			return err
+		}
+		if err := writeField(value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// maxEnvBinaryFieldLen bounds the key/value length ReadEnvBinary will
+// allocate for. Without it, a single corrupted or adversarial uvarint
+// length prefix could claim an arbitrarily large allocation before the
+// read that would actually fail it, crashing or OOMing the process reading
+// the pipe instead of returning a decode error.
+const maxEnvBinaryFieldLen = 1 << 24 // 16 MiB
+
+// ReadEnvBinary reads key/value pairs written by WriteEnvBinary from r into
+// the job's environment via Setenv.
+func (job *Job) ReadEnvBinary(r io.Reader) error {
+	br := bufio.NewReader(r)
+	readField := func() (string, error) {
+		n, err := binary.ReadUvarint(br)
+		if err != nil {
+			return "", err
+		}
+		if n > maxEnvBinaryFieldLen {
+			return "", fmt.Errorf("binary env field length %d exceeds the %d byte limit", n, maxEnvBinaryFieldLen)
+		}
+		buf := make([]byte, n)
+		if _, err := io.ReadFull(br, buf); err != nil {
+			return "", err
+		}
+		return string(buf), nil
+	}
+	for {
+		key, err := readField()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("reading binary env key: %w", err)
+		}
+		value, err := readField()
+		if err != nil {
+			return fmt.Errorf("reading binary env value for %q: %w", key, err)
+		}
+		job.Setenv(key, value)
+	}
+}
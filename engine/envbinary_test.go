@@ -0,0 +1,45 @@
+package engine // import "github.com/docker/docker/engine"
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+)
+
+func TestEnvBinaryRoundTripWithNewlinesAndNUL(t *testing.T) {
+	eng := New()
+	job := eng.Job("test")
+	job.Setenv("MULTILINE", "line one\nline two\x00line three")
+	job.Setenv("PLAIN", "ok")
+
+	var buf bytes.Buffer
+	if err := job.WriteEnvBinary(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	other := eng.Job("test2")
+	if err := other.ReadEnvBinary(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := other.Getenv("MULTILINE"); got != "line one\nline two\x00line three" {
+		t.Fatalf("unexpected MULTILINE value: %q", got)
+	}
+	if got := other.Getenv("PLAIN"); got != "ok" {
+		t.Fatalf("unexpected PLAIN value: %q", got)
+	}
+}
+
+func TestReadEnvBinaryRejectsOversizedLengthPrefix(t *testing.T) {
+	eng := New()
+	job := eng.Job("test")
+
+	var buf bytes.Buffer
+	varint := make([]byte, binary.MaxVarintLen64)
+	n := binary.PutUvarint(varint, maxEnvBinaryFieldLen+1)
+	buf.Write(varint[:n])
+
+	if err := job.ReadEnvBinary(&buf); err == nil {
+		t.Fatal("expected an error for a length prefix past the field size limit")
+	}
+}
@@ -0,0 +1,80 @@
+package engine // import "github.com/docker/docker/engine"
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// encryptedEnvPrefix marks a stored env value as AES-GCM ciphertext rather
+// than plaintext, so Getenv knows to decrypt it and serialization paths
+// (which read the raw stored value) emit the ciphertext as-is.
+const encryptedEnvPrefix = "enc:"
+
+// SetEncryptedEnv stores value under key, encrypted at rest with AES-GCM
+// under key32 (which must be 16, 24 or 32 bytes, selecting AES-128/192/256).
+// Getenv transparently decrypts it back to the plaintext value; every other
+// serialization path (EncodeEnvCodec, MarshalJSON, ...) sees only the stored
+// ciphertext, limiting plaintext exposure to the moment Getenv is called.
+func (job *Job) SetEncryptedEnv(key, value string, key32 []byte) error {
+	block, err := aes.NewCipher(key32)
+	if err != nil {
+		return fmt.Errorf("%s: %w", key, err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return fmt.Errorf("%s: %w", key, err)
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return fmt.Errorf("%s: %w", key, err)
+	}
+	ciphertext := gcm.Seal(nonce, nonce, []byte(value), nil)
+
+	job.env.Set(job.namespaced(key), encryptedEnvPrefix+base64.StdEncoding.EncodeToString(ciphertext))
+	if job.envEncryptionKeys == nil {
+		job.envEncryptionKeys = make(map[string][]byte)
+	}
+	job.envEncryptionKeys[key] = key32
+	return nil
+}
+
+// decryptEnvValue decrypts raw if it is ciphertext stored by
+// SetEncryptedEnv for key, returning ok=false otherwise so Getenv falls
+// through to its normal resolution.
+func (job *Job) decryptEnvValue(key, raw string) (plain string, ok bool) {
+	key32, known := job.envEncryptionKeys[key]
+	if !known || !strings.HasPrefix(raw, encryptedEnvPrefix) {
+		return "", false
+	}
+	ciphertext, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(raw, encryptedEnvPrefix))
+	if err != nil {
+		job.Warnf("%s: %v\n", key, err)
+		return "", false
+	}
+	block, err := aes.NewCipher(key32)
+	if err != nil {
+		job.Warnf("%s: %v\n", key, err)
+		return "", false
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		job.Warnf("%s: %v\n", key, err)
+		return "", false
+	}
+	if len(ciphertext) < gcm.NonceSize() {
+		job.Warnf("%s: ciphertext too short\n", key)
+		return "", false
+	}
+	nonce, sealed := ciphertext[:gcm.NonceSize()], ciphertext[gcm.NonceSize():]
+	value, err := gcm.Open(nil, nonce, sealed, nil)
+	if err != nil {
+		job.Warnf("%s: %v\n", key, err)
+		return "", false
+	}
+	return string(value), true
+}
@@ -0,0 +1,29 @@
+package engine // import "github.com/docker/docker/engine"
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestSetEncryptedEnv(t *testing.T) {
+	eng := New()
+	job := eng.Job("test")
+	key32 := []byte("0123456789abcdef0123456789abcdef")
+
+	if err := job.SetEncryptedEnv("SECRET", "swordfish", key32); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := job.Getenv("SECRET"); got != "swordfish" {
+		t.Fatalf("expected Getenv to decrypt to the plaintext, got %q", got)
+	}
+
+	var buf bytes.Buffer
+	if err := job.EncodeEnvExcept(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(buf.String(), "swordfish") {
+		t.Fatalf("expected serialized output to contain ciphertext, not plaintext, got %q", buf.String())
+	}
+}
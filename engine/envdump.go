@@ -0,0 +1,30 @@
+package engine // import "github.com/docker/docker/engine"
+
+import (
+	"sort"
+	"strings"
+)
+
+// EnvDump returns a human-readable, newline-joined snapshot of the job's
+// environment as sorted "KEY=VALUE" lines, with secret-looking values (per
+// secretKeyPattern) and redact-pattern matches (per AddRedactPattern)
+// masked. Unlike the machine-oriented wire format, it's meant for diffing
+// and review; non-secret lines can be parsed back with DecodeEnvFile.
+func (job *Job) EnvDump() string {
+	env := job.env.Map()
+	keys := make([]string, 0, len(env))
+	for key := range env {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	lines := make([]string, 0, len(keys))
+	for _, key := range keys {
+		value := env[key]
+		if secretKeyPattern.MatchString(key) {
+			value = "***"
+		}
+		lines = append(lines, key+"="+job.redact(value))
+	}
+	return strings.Join(lines, "\n")
+}
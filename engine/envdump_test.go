@@ -0,0 +1,31 @@
+package engine // import "github.com/docker/docker/engine"
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEnvDumpSortedAndRedacted(t *testing.T) {
+	eng := New()
+	job := eng.Job("test")
+	job.Setenv("ZEBRA", "z")
+	job.Setenv("API_TOKEN", "super-secret")
+	job.Setenv("ALPHA", "a")
+
+	dump := job.EnvDump()
+	lines := strings.Split(dump, "\n")
+	if !strings.HasPrefix(lines[0], "ALPHA=") {
+		t.Fatalf("expected ALPHA first, got %v", lines)
+	}
+	if lines[1] != "API_TOKEN=***" {
+		t.Fatalf("expected API_TOKEN redacted, got %q", lines[1])
+	}
+
+	other := eng.Job("test2")
+	if err := other.DecodeEnvFile(strings.NewReader("ALPHA=a\nZEBRA=z\n"), false); err != nil {
+		t.Fatal(err)
+	}
+	if other.Getenv("ALPHA") != "a" || other.Getenv("ZEBRA") != "z" {
+		t.Fatal("expected non-secret dump lines to round-trip through DecodeEnvFile")
+	}
+}
@@ -0,0 +1,25 @@
+package engine // import "github.com/docker/docker/engine"
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// EncodeEnvExcept writes the job's environment to dst as a JSON object,
+// omitting the named keys. It is useful for producing a sanitized view of
+// the environment, dropping internal bookkeeping keys that shouldn't be
+// exposed to a caller.
+func (job *Job) EncodeEnvExcept(dst io.Writer, keys ...string) error {
+	excluded := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		excluded[key] = true
+	}
+
+	out := make(map[string]string)
+	for k, v := range job.Environ() {
+		if !excluded[k] {
+			out[k] = v
+		}
+	}
+	return json.NewEncoder(dst).Encode(out)
+}
@@ -0,0 +1,30 @@
+package engine // import "github.com/docker/docker/engine"
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestEncodeEnvExcept(t *testing.T) {
+	eng := New()
+	job := eng.Job("test")
+	job.Setenv("PUBLIC", "value")
+	job.Setenv("INTERNAL_TOKEN", "secret")
+
+	var buf bytes.Buffer
+	if err := job.EncodeEnvExcept(&buf, "INTERNAL_TOKEN"); err != nil {
+		t.Fatal(err)
+	}
+
+	var out map[string]string
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatal(err)
+	}
+	if _, present := out["INTERNAL_TOKEN"]; present {
+		t.Fatal("expected INTERNAL_TOKEN to be excluded")
+	}
+	if out["PUBLIC"] != "value" {
+		t.Fatalf("expected PUBLIC to be present, got %q", out["PUBLIC"])
+	}
+}
@@ -0,0 +1,47 @@
+package engine // import "github.com/docker/docker/engine"
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+var envFileRefPattern = regexp.MustCompile(`\$\{([^}]*)\}`)
+
+// DecodeEnvFile reads "KEY=VALUE" lines from src into the job's
+// environment. Blank lines and lines starting with '#' are skipped.
+//
+// When interpolate is true, "${VAR}" references within a value are resolved
+// against the job's environment as lines are processed top-down, so a line
+// may reference a variable set by an earlier line. A reference to an
+// undefined variable expands to the empty string. When interpolate is
+// false, "${VAR}" is stored literally.
+func (job *Job) DecodeEnvFile(src io.Reader, interpolate bool) error {
+	scanner := bufio.NewScanner(src)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value, ok := splitKV(line)
+		if !ok {
+			return fmt.Errorf("invalid env file line: %q", line)
+		}
+		if interpolate {
+			value = job.expandEnvRefs(value)
+		}
+		job.Setenv(key, value)
+	}
+	return scanner.Err()
+}
+
+// expandEnvRefs replaces "${VAR}" references in value with the current
+// value of VAR in the job's environment, or "" if VAR is unset.
+func (job *Job) expandEnvRefs(value string) string {
+	return envFileRefPattern.ReplaceAllStringFunc(value, func(ref string) string {
+		name := envFileRefPattern.FindStringSubmatch(ref)[1]
+		return job.Getenv(name)
+	})
+}
@@ -0,0 +1,32 @@
+package engine // import "github.com/docker/docker/engine"
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDecodeEnvFileInterpolation(t *testing.T) {
+	eng := New()
+	job := eng.Job("test")
+
+	src := "HOST=example.com\nURL=https://${HOST}/path\n"
+	if err := job.DecodeEnvFile(strings.NewReader(src), true); err != nil {
+		t.Fatal(err)
+	}
+	if got := job.Getenv("URL"); got != "https://example.com/path" {
+		t.Fatalf("expected interpolated URL, got %q", got)
+	}
+}
+
+func TestDecodeEnvFileLiteral(t *testing.T) {
+	eng := New()
+	job := eng.Job("test")
+
+	src := "GREETING=${NAME}\n"
+	if err := job.DecodeEnvFile(strings.NewReader(src), false); err != nil {
+		t.Fatal(err)
+	}
+	if got := job.Getenv("GREETING"); got != "${NAME}" {
+		t.Fatalf("expected literal reference, got %q", got)
+	}
+}
@@ -0,0 +1,17 @@
+package engine // import "github.com/docker/docker/engine"
+
+// EnvInOrder returns the job's environment as key/value pairs in the order
+// each key was first set. Env already maintains this invariant internally
+// (Set updates a key in place rather than appending a duplicate), so this
+// simply exposes it in a form that doesn't collapse into an unordered map
+// the way Environ does -- useful for generating deterministic, reviewable
+// config dumps.
+func (job *Job) EnvInOrder() [][2]string {
+	pairs := make([][2]string, 0, len(job.env))
+	for _, kv := range job.env {
+		if k, v, ok := splitKV(kv); ok {
+			pairs = append(pairs, [2]string{k, v})
+		}
+	}
+	return pairs
+}
@@ -0,0 +1,19 @@
+package engine // import "github.com/docker/docker/engine"
+
+import "testing"
+
+func TestEnvInOrderPreservesInsertionOrder(t *testing.T) {
+	eng := New()
+	job := eng.Job("test")
+	job.Setenv("B", "1")
+	job.Setenv("A", "2")
+	job.Setenv("B", "3") // update in place, should not move to the end
+
+	pairs := job.EnvInOrder()
+	if len(pairs) != 2 {
+		t.Fatalf("expected 2 pairs, got %v", pairs)
+	}
+	if pairs[0] != [2]string{"B", "3"} || pairs[1] != [2]string{"A", "2"} {
+		t.Fatalf("expected [[B 3] [A 2]], got %v", pairs)
+	}
+}
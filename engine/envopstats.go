@@ -0,0 +1,29 @@
+package engine // import "github.com/docker/docker/engine"
+
+import "sync/atomic"
+
+// envOpCounters holds concurrency-safe counters for env operations,
+// embedded in Engine.
+type envOpCounters struct {
+	getenv  int64
+	setenv  int64
+	environ int64
+}
+
+// EnvOpStats reports the number of Getenv, Setenv, and Environ calls made
+// by jobs belonging to this Engine, to help diagnose handlers thrashing
+// the env.
+type EnvOpStats struct {
+	Getenv  int64
+	Setenv  int64
+	Environ int64
+}
+
+// EnvOpStats returns a snapshot of the engine's env operation counters.
+func (eng *Engine) EnvOpStats() EnvOpStats {
+	return EnvOpStats{
+		Getenv:  atomic.LoadInt64(&eng.envOpStats.getenv),
+		Setenv:  atomic.LoadInt64(&eng.envOpStats.setenv),
+		Environ: atomic.LoadInt64(&eng.envOpStats.environ),
+	}
+}
@@ -0,0 +1,33 @@
+package engine // import "github.com/docker/docker/engine"
+
+import "testing"
+
+func TestEnvOpStats(t *testing.T) {
+	eng := New()
+	job := eng.Job("test")
+
+	job.Setenv("A", "1")
+	job.Getenv("A")
+	job.Getenv("A")
+
+	stats := eng.EnvOpStats()
+	if stats.Setenv != 1 {
+		t.Fatalf("expected 1 Setenv, got %d", stats.Setenv)
+	}
+	if stats.Getenv != 2 {
+		t.Fatalf("expected 2 Getenv, got %d", stats.Getenv)
+	}
+}
+
+func TestEnvOpStatsGetenvCountsOnceThroughAlias(t *testing.T) {
+	eng := New()
+	eng.AliasEnv("HTTP_PROXY", "http_proxy")
+
+	job := eng.Job("test")
+	job.Setenv("http_proxy", "http://proxy:8080")
+	job.Getenv("HTTP_PROXY")
+
+	if stats := eng.EnvOpStats(); stats.Getenv != 1 {
+		t.Fatalf("expected resolving one key through an alias to count as 1 Getenv, got %d", stats.Getenv)
+	}
+}
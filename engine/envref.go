@@ -0,0 +1,50 @@
+package engine // import "github.com/docker/docker/engine"
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+const fileRefPrefix = "file://"
+
+// resolveEnvRef resolves value if it is a file reference: a "@path" or
+// "file://path" value causes the referenced file's contents to be read
+// (lazily, on first access) and cached on the job for subsequent reads.
+// A value that isn't a file reference is returned unchanged.
+func (job *Job) resolveEnvRef(value string) (string, error) {
+	var path string
+	switch {
+	case strings.HasPrefix(value, "@"):
+		path = value[1:]
+	case strings.HasPrefix(value, fileRefPrefix):
+		path = value[len(fileRefPrefix):]
+	default:
+		return value, nil
+	}
+
+	if job.fileCache != nil {
+		if cached, ok := job.fileCache[path]; ok {
+			return cached, nil
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("reading env file reference %q: %w", path, err)
+	}
+	content := string(data)
+	if job.fileCache == nil {
+		job.fileCache = make(map[string]string)
+	}
+	job.fileCache[path] = content
+	return content, nil
+}
+
+// GetenvFileStrict behaves like Getenv -- including namespacing, secrets,
+// alias fallback, SetFallback chaining, decryption, and the engine's
+// EnvResolver -- but returns an error instead of logging a warning and
+// returning "" when a file reference can't be read.
+func (job *Job) GetenvFileStrict(key string) (string, error) {
+	return job.resolveGetenv(key)
+}
@@ -0,0 +1,57 @@
+package engine // import "github.com/docker/docker/engine"
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGetenvFileReference(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "token")
+	if err := os.WriteFile(path, []byte("s3cr3t"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	eng := New()
+	job := eng.Job("test")
+	job.Setenv("TOKEN", "@"+path)
+
+	if got := job.Getenv("TOKEN"); got != "s3cr3t" {
+		t.Fatalf("expected file contents, got %q", got)
+	}
+}
+
+func TestGetenvFileStrictReportsMissingFile(t *testing.T) {
+	eng := New()
+	job := eng.Job("test")
+	job.Setenv("TOKEN", "@/no/such/file")
+
+	if _, err := job.GetenvFileStrict("TOKEN"); err == nil {
+		t.Fatal("expected an error for an unreadable file")
+	}
+	if got := job.Getenv("TOKEN"); got != "" {
+		t.Fatalf("expected empty string from the lenient variant, got %q", got)
+	}
+}
+
+func TestGetenvFileStrictHonorsNamespace(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "token")
+	if err := os.WriteFile(path, []byte("s3cr3t"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	eng := New()
+	job := eng.Job("test")
+	job.SetEnvNamespace("NS")
+	job.Setenv("TOKEN", "@"+path)
+
+	got, err := job.GetenvFileStrict("TOKEN")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "s3cr3t" {
+		t.Fatalf("expected the namespaced key to resolve like Getenv, got %q", got)
+	}
+}
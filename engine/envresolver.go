@@ -0,0 +1,10 @@
+package engine // import "github.com/docker/docker/engine"
+
+// SetEnvResolver installs fn to post-process every value returned by Getenv
+// for jobs created by this Engine, receiving the job, the key, and the raw
+// value otherwise about to be returned. This gives an engine-wide hook for
+// decrypting secrets, applying defaults, or similar, without touching every
+// handler. When unset, Getenv behaves normally.
+func (eng *Engine) SetEnvResolver(fn func(job *Job, key, raw string) string) {
+	eng.envResolver = fn
+}
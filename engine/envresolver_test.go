@@ -0,0 +1,17 @@
+package engine // import "github.com/docker/docker/engine"
+
+import "testing"
+
+func TestSetEnvResolver(t *testing.T) {
+	eng := New()
+	eng.SetEnvResolver(func(job *Job, key, raw string) string {
+		return "resolved:" + raw
+	})
+
+	job := eng.Job("test")
+	job.Setenv("NAME", "alice")
+
+	if got := job.Getenv("NAME"); got != "resolved:alice" {
+		t.Fatalf("expected resolver to transform value, got %q", got)
+	}
+}
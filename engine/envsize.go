@@ -0,0 +1,14 @@
+package engine // import "github.com/docker/docker/engine"
+
+// EnvByteSize returns the total size, in bytes, of the job's environment
+// as it would be written out in "KEY=VALUE" form, counting each key once
+// even if Env holds a stale duplicate. Callers such as a scheduler
+// enforcing a per-tenant env quota can use it to pre-check a job before
+// accepting it.
+func (job *Job) EnvByteSize() int {
+	size := 0
+	for key, value := range job.env.Map() {
+		size += len(key) + len("=") + len(value)
+	}
+	return size
+}
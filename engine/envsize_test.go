@@ -0,0 +1,15 @@
+package engine // import "github.com/docker/docker/engine"
+
+import "testing"
+
+func TestEnvByteSize(t *testing.T) {
+	eng := New()
+	job := eng.Job("test")
+	job.Setenv("A", "1")
+	job.Setenv("BC", "23")
+
+	want := len("A=1") + len("BC=23")
+	if got := job.EnvByteSize(); got != want {
+		t.Fatalf("expected %d, got %d", want, got)
+	}
+}
@@ -0,0 +1,30 @@
+package engine // import "github.com/docker/docker/engine"
+
+// envSizeWarnConfig holds the state behind SetEnvSizeWarn: the threshold
+// to watch for and the callback to fire the first time it's crossed.
+type envSizeWarnConfig struct {
+	threshold int
+	fn        func(size int)
+	fired     bool
+}
+
+// SetEnvSizeWarn arranges for fn to be called the first time the job's
+// environment, as reported by EnvByteSize, reaches threshold bytes during
+// Setenv (including indirectly, via DecodeEnv and friends). This surfaces
+// runaway env growth early, before it trips a hard limit elsewhere.
+func (job *Job) SetEnvSizeWarn(threshold int, fn func(size int)) {
+	job.envSizeWarn = &envSizeWarnConfig{threshold: threshold, fn: fn}
+}
+
+// checkEnvSizeWarn fires the SetEnvSizeWarn callback if the env has just
+// crossed its threshold for the first time.
+func (job *Job) checkEnvSizeWarn() {
+	warn := job.envSizeWarn
+	if warn == nil || warn.fired {
+		return
+	}
+	if size := job.EnvByteSize(); size >= warn.threshold {
+		warn.fired = true
+		warn.fn(size)
+	}
+}
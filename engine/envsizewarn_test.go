@@ -0,0 +1,26 @@
+package engine // import "github.com/docker/docker/engine"
+
+import "testing"
+
+func TestSetEnvSizeWarnFiresOnce(t *testing.T) {
+	eng := New()
+	job := eng.Job("test")
+
+	fired := 0
+	job.SetEnvSizeWarn(10, func(size int) { fired++ })
+
+	job.Setenv("A", "short")
+	if fired != 0 {
+		t.Fatalf("expected no warning yet, fired=%d", fired)
+	}
+
+	job.Setenv("B", "long-enough-value")
+	if fired != 1 {
+		t.Fatalf("expected exactly one warning after crossing the threshold, fired=%d", fired)
+	}
+
+	job.Setenv("C", "another-long-value")
+	if fired != 1 {
+		t.Fatalf("expected the warning not to fire again, fired=%d", fired)
+	}
+}
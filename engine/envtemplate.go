@@ -0,0 +1,41 @@
+package engine // import "github.com/docker/docker/engine"
+
+import (
+	"strings"
+	"text/template"
+)
+
+// SetenvTemplate registers tmpl, a text/template referencing other env keys
+// as fields (e.g. "{{.HOST}}:{{.PORT}}"), as the value of key. The template
+// is parsed immediately, so a syntax error is returned from SetenvTemplate
+// itself rather than surfacing later from Getenv. It is rendered against
+// the job's environment each time Getenv(key) is called, so it always
+// reflects the current values of the variables it references.
+func (job *Job) SetenvTemplate(key, tmpl string) error {
+	t, err := template.New(key).Parse(tmpl)
+	if err != nil {
+		return err
+	}
+	if job.templates == nil {
+		job.templates = make(map[string]*template.Template)
+	}
+	job.templates[key] = t
+	return nil
+}
+
+// renderTemplate renders the template registered for key against the job's
+// environment, returning ok=false if no template is registered for key. A
+// render error yields an empty string and logs a warning, matching
+// Getenv's handling of other unreadable values.
+func (job *Job) renderTemplate(key string) (value string, ok bool) {
+	t, exists := job.templates[key]
+	if !exists {
+		return "", false
+	}
+	var buf strings.Builder
+	if err := t.Execute(&buf, job.env.Map()); err != nil {
+		job.Warnf("%s: template error: %v\n", key, err)
+		return "", true
+	}
+	return buf.String(), true
+}
@@ -0,0 +1,18 @@
+package engine // import "github.com/docker/docker/engine"
+
+import "testing"
+
+func TestSetenvTemplate(t *testing.T) {
+	eng := New()
+	job := eng.Job("test")
+	job.Setenv("HOST", "db.internal")
+	job.Setenv("PORT", "5432")
+
+	if err := job.SetenvTemplate("ADDR", "{{.HOST}}:{{.PORT}}"); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := job.Getenv("ADDR"); got != "db.internal:5432" {
+		t.Fatalf("expected db.internal:5432, got %q", got)
+	}
+}
@@ -0,0 +1,19 @@
+package engine // import "github.com/docker/docker/engine"
+
+import "errors"
+
+// Sentinel errors for the failure modes the Run family (Run, RunContext,
+// RunResult, RunSafely, RunHardTimeout, ...) can report,
+// letting callers branch with errors.Is instead of comparing status
+// strings. The mapping from status to sentinel is: StatusNotFound ->
+// ErrHandlerNotFound, StatusCancelled -> ErrCancelled, StatusTimeout ->
+// ErrTimeout, StatusPanic -> ErrPanic.
+var (
+	ErrTimeout         = errors.New("job timed out")
+	ErrCancelled       = errors.New("job cancelled")
+	ErrPanic           = errors.New("job handler panicked")
+	ErrHandlerNotFound = errors.New("job handler not found")
+	// ErrSkipped is recorded by RunGraph for a job that was never run
+	// because one of its dependencies failed.
+	ErrSkipped = errors.New("job skipped: upstream dependency failed")
+)
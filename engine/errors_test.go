@@ -0,0 +1,39 @@
+package engine // import "github.com/docker/docker/engine"
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRunErrorSentinels(t *testing.T) {
+	eng := New()
+
+	notFound := eng.Job("missing")
+	if err := notFound.Run(); !errors.Is(err, ErrHandlerNotFound) {
+		t.Fatalf("expected ErrHandlerNotFound, got %v", err)
+	}
+
+	eng.Register("panics", func(*Job) string { panic("boom") })
+	panicky := eng.Job("panics")
+	if err := panicky.RunResult().Err; !errors.Is(err, ErrPanic) {
+		t.Fatalf("expected ErrPanic, got %v", err)
+	}
+
+	eng.Register("hangs", func(*Job) string {
+		time.Sleep(time.Hour)
+		return StatusOK
+	})
+	cancelled := eng.Job("hangs")
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := cancelled.RunContext(ctx); !errors.Is(err, ErrCancelled) {
+		t.Fatalf("expected ErrCancelled, got %v", err)
+	}
+
+	timesOut := eng.Job("hangs")
+	if err := eng.RunSafely(timesOut, 10*time.Millisecond, 1024); !errors.Is(err, ErrTimeout) {
+		t.Fatalf("expected ErrTimeout, got %v", err)
+	}
+}
@@ -0,0 +1,41 @@
+package engine // import "github.com/docker/docker/engine"
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// EventRecorder captures every event a job emits via Emit, for asserting
+// on progress-reporting handlers in tests.
+type EventRecorder struct {
+	events []Event
+}
+
+// Events returns every event captured so far, in emission order.
+func (r *EventRecorder) Events() []Event {
+	return r.events
+}
+
+// AssertEmitted fails t unless an event named name was captured.
+func (r *EventRecorder) AssertEmitted(t testing.TB, name string) {
+	for _, e := range r.events {
+		if e.Name == name {
+			return
+		}
+	}
+	t.Fatalf("expected an event named %q, got %v", name, r.events)
+}
+
+// RecordEvents installs a recorder as the job's Stdout, capturing every
+// event the job emits via Emit (including Progress and the heartbeat) so
+// tests can assert on them without parsing NDJSON by hand.
+func (job *Job) RecordEvents() *EventRecorder {
+	r := &EventRecorder{}
+	job.Stdout = &lineWriter{fn: func(line string) {
+		var e Event
+		if err := json.Unmarshal([]byte(line), &e); err == nil {
+			r.events = append(r.events, e)
+		}
+	}}
+	return r
+}
@@ -0,0 +1,21 @@
+package engine // import "github.com/docker/docker/engine"
+
+import "testing"
+
+func TestRecordEvents(t *testing.T) {
+	eng := New()
+	eng.Register("progresses", func(job *Job) string {
+		job.Progress(1, 10)
+		job.Emit("done", nil)
+		return StatusOK
+	})
+	job := eng.Job("progresses")
+	recorder := job.RecordEvents()
+
+	if err := job.Run(); err != nil {
+		t.Fatal(err)
+	}
+
+	recorder.AssertEmitted(t, "progress")
+	recorder.AssertEmitted(t, "done")
+}
@@ -0,0 +1,69 @@
+package engine // import "github.com/docker/docker/engine"
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// Event is a single structured event emitted by a job via Emit, consumed
+// as newline-delimited JSON by a client (see ReadEvents).
+type Event struct {
+	Name string                 `json:"name"`
+	Data map[string]interface{} `json:"data,omitempty"`
+}
+
+// context returns the job's run context, defaulting to context.Background
+// for jobs run via Run rather than RunContext.
+func (job *Job) context() context.Context {
+	if job.ctx != nil {
+		return job.ctx
+	}
+	return context.Background()
+}
+
+// Emit writes event as a newline-delimited JSON object to Stdout. If the
+// job's run context has been cancelled, Emit is a no-op and returns the
+// context's error, so jobs don't keep reporting progress after the caller
+// has stopped listening.
+func (job *Job) Emit(name string, data map[string]interface{}) error {
+	if err := job.context().Err(); err != nil {
+		return err
+	}
+	b, err := json.Marshal(Event{Name: name, Data: data})
+	if err != nil {
+		return err
+	}
+	_, err = job.Stdout.Write(append(b, '\n'))
+	return err
+}
+
+// Progress emits a "progress" event carrying current/total counters.
+func (job *Job) Progress(current, total int64) error {
+	return job.Emit("progress", map[string]interface{}{
+		"current": current,
+		"total":   total,
+	})
+}
+
+// StartHeartbeat emits a "heartbeat" event every interval until the job's
+// run context is cancelled, then exits promptly. The returned stop func
+// blocks until the heartbeat goroutine has exited, for deterministic
+// shutdown.
+func (job *Job) StartHeartbeat(interval time.Duration) (stop func()) {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		clock := job.getClock()
+		ctx := job.context()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-clock.After(interval):
+				job.Emit("heartbeat", nil)
+			}
+		}
+	}()
+	return func() { <-done }
+}
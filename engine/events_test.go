@@ -0,0 +1,30 @@
+package engine // import "github.com/docker/docker/engine"
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestStartHeartbeatStopsOnCancel(t *testing.T) {
+	eng := New()
+	job := eng.Job("test")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	job.ctx = ctx
+
+	stop := job.StartHeartbeat(time.Millisecond)
+	cancel()
+
+	done := make(chan struct{})
+	go func() {
+		stop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("expected heartbeat goroutine to exit promptly after cancellation")
+	}
+}
@@ -0,0 +1,34 @@
+package engine // import "github.com/docker/docker/engine"
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ExportAll serializes the job's environment in every format this package
+// supports, for commands that want to dump config in several
+// representations at once (e.g. "config dump --all").
+func (job *Job) ExportAll() (jsonBytes, envFileBytes, yamlBytes []byte, err error) {
+	env := job.env.Map()
+
+	jsonBytes, err = json.Marshal(env)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	var buf bytes.Buffer
+	for k, v := range env {
+		fmt.Fprintf(&buf, "%s=%s\n", k, v)
+	}
+	envFileBytes = buf.Bytes()
+
+	yamlBytes, err = yaml.Marshal(env)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return jsonBytes, envFileBytes, yamlBytes, nil
+}
@@ -0,0 +1,44 @@
+package engine // import "github.com/docker/docker/engine"
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestExportAll(t *testing.T) {
+	eng := New()
+	job := eng.Job("test")
+	job.Setenv("NAME", "value")
+
+	jsonBytes, envFileBytes, yamlBytes, err := job.ExportAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var fromJSON map[string]string
+	if err := json.Unmarshal(jsonBytes, &fromJSON); err != nil {
+		t.Fatal(err)
+	}
+	if fromJSON["NAME"] != "value" {
+		t.Fatalf("json export missing NAME, got %#v", fromJSON)
+	}
+
+	other := eng.Job("test")
+	if err := other.DecodeEnvFile(bytes.NewReader(envFileBytes), false); err != nil {
+		t.Fatal(err)
+	}
+	if other.Getenv("NAME") != "value" {
+		t.Fatalf("envfile export missing NAME, got %q", other.Getenv("NAME"))
+	}
+
+	var fromYAML map[string]string
+	if err := yaml.Unmarshal(yamlBytes, &fromYAML); err != nil {
+		t.Fatal(err)
+	}
+	if fromYAML["NAME"] != "value" {
+		t.Fatalf("yaml export missing NAME, got %#v", fromYAML)
+	}
+}
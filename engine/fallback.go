@@ -0,0 +1,26 @@
+package engine // import "github.com/docker/docker/engine"
+
+// SetFallback configures parent as the job's fallback for env lookups:
+// Getenv and HasEnv consult parent for any key not set locally, without
+// copying parent's values into the job. Local values always shadow the
+// parent's, so callers can override specific keys while inheriting the
+// rest. This models layered configuration (e.g. a per-request job falling
+// back to engine-wide defaults) without duplicating data.
+func (job *Job) SetFallback(parent *Job) {
+	job.fallback = parent
+}
+
+// HasEnv reports whether key is set, either locally or, failing that, on
+// the job's fallback chain.
+func (job *Job) HasEnv(key string) bool {
+	if job.env.Exists(job.namespaced(key)) {
+		return true
+	}
+	if _, ok := job.getSecret(key); ok {
+		return true
+	}
+	if job.fallback != nil {
+		return job.fallback.HasEnv(key)
+	}
+	return false
+}
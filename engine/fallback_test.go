@@ -0,0 +1,24 @@
+package engine // import "github.com/docker/docker/engine"
+
+import "testing"
+
+func TestSetFallback(t *testing.T) {
+	eng := New()
+	parent := eng.Job("parent")
+	parent.Setenv("REGION", "us-east-1")
+	parent.Setenv("TIER", "default")
+
+	child := eng.Job("child")
+	child.SetFallback(parent)
+	child.Setenv("TIER", "premium")
+
+	if got := child.Getenv("REGION"); got != "us-east-1" {
+		t.Fatalf("expected child to inherit REGION from parent, got %q", got)
+	}
+	if got := child.Getenv("TIER"); got != "premium" {
+		t.Fatalf("expected child's local TIER to win, got %q", got)
+	}
+	if !child.HasEnv("REGION") {
+		t.Fatal("expected HasEnv to report true via fallback")
+	}
+}
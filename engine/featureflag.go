@@ -0,0 +1,28 @@
+package engine // import "github.com/docker/docker/engine"
+
+import "strings"
+
+// SetDefaultFlag configures the engine-wide default for feature flag name,
+// consulted by FeatureEnabled when a job hasn't set FEATURE_<NAME> itself.
+func (eng *Engine) SetDefaultFlag(name string, enabled bool) {
+	if eng.defaultFlags == nil {
+		eng.defaultFlags = make(map[string]bool)
+	}
+	eng.defaultFlags[name] = enabled
+}
+
+// FeatureEnabled reports whether feature flag name is enabled for this
+// job, read from the env key FEATURE_<NAME> (uppercased, with hyphens
+// turned into underscores) using the same boolean rules as GetBool. If the
+// job's env doesn't set the key, it falls back to the engine-wide default
+// configured via SetDefaultFlag, or false if neither is set.
+func (job *Job) FeatureEnabled(name string) bool {
+	key := "FEATURE_" + strings.ToUpper(strings.ReplaceAll(name, "-", "_"))
+	if ok, exists := job.GetenvBoolOk(key); exists {
+		return ok
+	}
+	if job.Eng != nil {
+		return job.Eng.defaultFlags[name]
+	}
+	return false
+}
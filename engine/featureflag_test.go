@@ -0,0 +1,19 @@
+package engine // import "github.com/docker/docker/engine"
+
+import "testing"
+
+func TestFeatureEnabledOverridesAndDefault(t *testing.T) {
+	eng := New()
+	eng.SetDefaultFlag("new-ui", true)
+
+	fallback := eng.Job("test")
+	if !fallback.FeatureEnabled("new-ui") {
+		t.Fatal("expected unset flag to use the engine default")
+	}
+
+	override := eng.Job("test")
+	override.Setenv("FEATURE_NEW_UI", "false")
+	if override.FeatureEnabled("new-ui") {
+		t.Fatal("expected job-level flag to override the engine default")
+	}
+}
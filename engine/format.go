@@ -0,0 +1,37 @@
+package engine // import "github.com/docker/docker/engine"
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// OutputFormat selects how WriteFormatted renders a value.
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+// SetOutputFormat sets the format WriteFormatted uses to render values to
+// Stdout. Handlers should call WriteFormatted rather than hardcoding a
+// format, so callers can negotiate json/text output.
+func (job *Job) SetOutputFormat(format string) {
+	job.outputFormat = format
+}
+
+// WriteFormatted renders v to Stdout according to the format set with
+// SetOutputFormat, defaulting to FormatText (fmt.Sprintf("%v", v)) if none
+// was set.
+func (job *Job) WriteFormatted(v interface{}) error {
+	switch job.outputFormat {
+	case FormatJSON:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		_, err = job.Stdout.Write(append(b, '\n'))
+		return err
+	default:
+		_, err := fmt.Fprintf(job.Stdout, "%v\n", v)
+		return err
+	}
+}
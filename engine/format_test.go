@@ -0,0 +1,32 @@
+package engine // import "github.com/docker/docker/engine"
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWriteFormatted(t *testing.T) {
+	eng := New()
+
+	job := eng.Job("test")
+	var textOut bytes.Buffer
+	job.Stdout = &textOut
+	if err := job.WriteFormatted(map[string]int{"a": 1}); err != nil {
+		t.Fatal(err)
+	}
+
+	jsonJob := eng.Job("test")
+	var jsonOut bytes.Buffer
+	jsonJob.Stdout = &jsonOut
+	jsonJob.SetOutputFormat(FormatJSON)
+	if err := jsonJob.WriteFormatted(map[string]int{"a": 1}); err != nil {
+		t.Fatal(err)
+	}
+
+	if textOut.String() == jsonOut.String() {
+		t.Fatalf("expected different rendering for text vs json, got identical output %q", textOut.String())
+	}
+	if jsonOut.String() != "{\"a\":1}\n" {
+		t.Fatalf("unexpected json output: %q", jsonOut.String())
+	}
+}
@@ -0,0 +1,29 @@
+package engine // import "github.com/docker/docker/engine"
+
+import (
+	"encoding/json"
+	"strconv"
+)
+
+// GetenvAny returns the value of key, inferring its type by attempting to
+// parse it in turn as a bool, an int, a float, and finally arbitrary JSON,
+// falling back to the raw string if none apply. This suits generic tooling
+// that needs to echo config back with its original type rather than
+// treating everything as a string.
+func (job *Job) GetenvAny(key string) interface{} {
+	raw := job.Getenv(key)
+	if b, err := strconv.ParseBool(raw); err == nil {
+		return b
+	}
+	if i, err := strconv.ParseInt(raw, 10, 64); err == nil {
+		return i
+	}
+	if f, err := strconv.ParseFloat(raw, 64); err == nil {
+		return f
+	}
+	var v interface{}
+	if err := json.Unmarshal([]byte(raw), &v); err == nil {
+		return v
+	}
+	return raw
+}
@@ -0,0 +1,17 @@
+package engine // import "github.com/docker/docker/engine"
+
+import "testing"
+
+func TestGetenvAny(t *testing.T) {
+	eng := New()
+	job := eng.Job("test")
+	job.Setenv("FLAG", "true")
+	job.Setenv("NAME", "abc")
+
+	if v, ok := job.GetenvAny("FLAG").(bool); !ok || !v {
+		t.Fatalf("expected FLAG to infer as bool true, got %#v", job.GetenvAny("FLAG"))
+	}
+	if v, ok := job.GetenvAny("NAME").(string); !ok || v != "abc" {
+		t.Fatalf("expected NAME to infer as string %q, got %#v", "abc", job.GetenvAny("NAME"))
+	}
+}
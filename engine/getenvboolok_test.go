@@ -0,0 +1,22 @@
+package engine // import "github.com/docker/docker/engine"
+
+import "testing"
+
+func TestGetenvBoolOk(t *testing.T) {
+	eng := New()
+	job := eng.Job("test")
+
+	if value, ok := job.GetenvBoolOk("ABSENT"); ok || value {
+		t.Fatalf("expected false, false for absent key, got %v, %v", value, ok)
+	}
+
+	job.SetenvBool("ENABLED", false)
+	if value, ok := job.GetenvBoolOk("ENABLED"); !ok || value {
+		t.Fatalf("expected false, true for explicitly-false key, got %v, %v", value, ok)
+	}
+
+	job.SetenvBool("ENABLED", true)
+	if value, ok := job.GetenvBoolOk("ENABLED"); !ok || !value {
+		t.Fatalf("expected true, true for explicitly-true key, got %v, %v", value, ok)
+	}
+}
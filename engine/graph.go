@@ -0,0 +1,61 @@
+package engine // import "github.com/docker/docker/engine"
+
+// RunGraph runs every job in graph, a map from job to the jobs it depends
+// on, running each only once all its dependencies have completed
+// successfully. If a dependency fails, jobs that depend on it (directly or
+// transitively) are not run and are recorded with ErrSkipped instead,
+// rather than running against a result they can't trust. Results for every
+// node are available afterward via LastGraphResults. It returns the first
+// non-nil error encountered, if any.
+//
+// RunGraph assumes graph is acyclic; a cycle simply leaves the jobs
+// involved unresolved and unrun.
+func (eng *Engine) RunGraph(graph map[*Job][]*Job) error {
+	results := make(map[*Job]error, len(graph))
+	for len(results) < len(graph) {
+		progressed := false
+		for job, deps := range graph {
+			if _, done := results[job]; done {
+				continue
+			}
+			ready, skip := true, false
+			for _, dep := range deps {
+				err, done := results[dep]
+				if !done {
+					ready = false
+					break
+				}
+				if err != nil {
+					skip = true
+				}
+			}
+			if !ready {
+				continue
+			}
+			if skip {
+				results[job] = ErrSkipped
+			} else {
+				results[job] = job.Run()
+			}
+			progressed = true
+		}
+		if !progressed {
+			break
+		}
+	}
+
+	eng.lastGraphResults = results
+	var firstErr error
+	for _, err := range results {
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// LastGraphResults returns the per-job outcome of the most recent RunGraph
+// call, including jobs skipped with ErrSkipped due to an upstream failure.
+func (eng *Engine) LastGraphResults() map[*Job]error {
+	return eng.lastGraphResults
+}
@@ -0,0 +1,30 @@
+package engine // import "github.com/docker/docker/engine"
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRunGraphSkipsDownstreamOfFailure(t *testing.T) {
+	eng := New()
+	eng.Register("build", func(*Job) string { return StatusErr })
+	eng.Register("deploy", func(*Job) string { return StatusOK })
+
+	build := eng.Job("build")
+	deploy := eng.Job("deploy")
+
+	graph := map[*Job][]*Job{
+		build:  nil,
+		deploy: {build},
+	}
+
+	eng.RunGraph(graph)
+	results := eng.LastGraphResults()
+
+	if results[build] == nil {
+		t.Fatal("expected build to fail")
+	}
+	if !errors.Is(results[deploy], ErrSkipped) {
+		t.Fatalf("expected deploy to be skipped, got %v", results[deploy])
+	}
+}
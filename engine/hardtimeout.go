@@ -0,0 +1,38 @@
+package engine // import "github.com/docker/docker/engine"
+
+import (
+	"fmt"
+	"time"
+)
+
+// RunHardTimeout runs the job's handler like Run, but guarantees the
+// caller unblocks after d even if the handler never returns: on timeout it
+// returns immediately with an error wrapping ErrTimeout and abandons the
+// handler goroutine, which keeps running to completion in the background.
+// This leaks a goroutine for the lifetime of the abandoned handler, so it
+// should only be used for handlers that cannot be trusted to honor
+// cancellation and where that leak is an acceptable cost; prefer
+// RunContext for anything that checks its context.
+func (job *Job) RunHardTimeout(d time.Duration) error {
+	if job.handler == nil {
+		job.status = StatusNotFound
+		return fmt.Errorf("%s: %w", job.Name, ErrHandlerNotFound)
+	}
+
+	done := make(chan string, 1)
+	go func() {
+		done <- job.handler(job)
+	}()
+
+	select {
+	case status := <-done:
+		job.status = status
+		if !job.Succeeded() {
+			return fmt.Errorf("%s: %s", job.Name, status)
+		}
+		return nil
+	case <-time.After(d):
+		job.status = StatusTimeout
+		return fmt.Errorf("%s: %w after %s", job.Name, ErrTimeout, d)
+	}
+}
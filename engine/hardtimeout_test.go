@@ -0,0 +1,26 @@
+package engine // import "github.com/docker/docker/engine"
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRunHardTimeoutReturnsAtDeadline(t *testing.T) {
+	eng := New()
+	job := eng.Job("hangs")
+	job.handler = func(*Job) string {
+		select {} // never returns
+	}
+
+	start := time.Now()
+	err := job.RunHardTimeout(20 * time.Millisecond)
+	elapsed := time.Since(start)
+
+	if !errors.Is(err, ErrTimeout) {
+		t.Fatalf("expected ErrTimeout, got %v", err)
+	}
+	if elapsed > 500*time.Millisecond {
+		t.Fatalf("expected to unblock at roughly the deadline, took %s", elapsed)
+	}
+}
@@ -0,0 +1,38 @@
+package engine // import "github.com/docker/docker/engine"
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+)
+
+// Hash computes a stable SHA-256 fingerprint of the job's definition: its
+// name, sorted arguments, and sorted environment. Streams are never
+// included. Two jobs with the same name, arguments, and environment
+// produce the same hash regardless of the order Setenv was called in. This
+// underpins caching and deduplication of job runs.
+func (job *Job) Hash() string {
+	args := append([]string{}, job.Args...)
+	sort.Strings(args)
+
+	env := job.env.Map()
+	keys := make([]string, 0, len(env))
+	for k := range env {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	h := sha256.New()
+	h.Write([]byte(job.Name))
+	for _, a := range args {
+		h.Write([]byte{0})
+		h.Write([]byte(a))
+	}
+	for _, k := range keys {
+		h.Write([]byte{0})
+		h.Write([]byte(k))
+		h.Write([]byte{0})
+		h.Write([]byte(env[k]))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,25 @@
+package engine // import "github.com/docker/docker/engine"
+
+import "testing"
+
+func TestHashStableAcrossSetenvOrder(t *testing.T) {
+	eng := New()
+
+	a := eng.Job("build", "x", "y")
+	a.Setenv("A", "1")
+	a.Setenv("B", "2")
+
+	b := eng.Job("build", "x", "y")
+	b.Setenv("B", "2")
+	b.Setenv("A", "1")
+
+	if a.Hash() != b.Hash() {
+		t.Fatalf("expected identical hashes, got %s and %s", a.Hash(), b.Hash())
+	}
+
+	c := eng.Job("build", "x", "y")
+	c.Setenv("A", "different")
+	if a.Hash() == c.Hash() {
+		t.Fatal("expected different hashes for different env values")
+	}
+}
@@ -0,0 +1,46 @@
+package engine // import "github.com/docker/docker/engine"
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// wireJob is the JSON wire format for a Job, shared between MarshalJSON and
+// ServeHTTP.
+type wireJob struct {
+	Name string
+	Args []string
+	Env  Env
+}
+
+// MarshalJSON serializes the job's name, arguments and environment for
+// transport to a remote engine. Stdin/Stdout/Stderr are not part of the
+// definition and are never included.
+func (job *Job) MarshalJSON() ([]byte, error) {
+	return json.Marshal(wireJob{
+		Name: job.Name,
+		Args: job.Args,
+		Env:  job.env,
+	})
+}
+
+// ServeHTTP decodes a Job definition from the request body, runs it, and
+// streams the handler's Stdout to the response body. The final status is
+// reported in the "X-Job-Status" trailer, since it is only known once the
+// handler has finished writing output.
+func (eng *Engine) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var wj wireJob
+	if err := json.NewDecoder(r.Body).Decode(&wj); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	job := eng.Job(wj.Name, wj.Args...)
+	job.env = wj.Env
+	job.Stdout = w
+
+	w.Header().Set("Trailer", "X-Job-Status")
+	w.WriteHeader(http.StatusOK)
+	job.RunContext(r.Context())
+	w.Header().Set("X-Job-Status", job.Status())
+}
@@ -0,0 +1,26 @@
+package engine // import "github.com/docker/docker/engine"
+
+import "context"
+
+// envContextKey is the well-known context key ImportEnvFromContext reads
+// from, populated by upstream middleware via context.WithValue.
+type envContextKey struct{}
+
+// EnvContextKey is the context key under which middleware should store a
+// map[string]string for ImportEnvFromContext to pick up.
+var EnvContextKey envContextKey
+
+// ImportEnvFromContext reads a map[string]string stored under
+// EnvContextKey in ctx and sets each entry as job env. This bridges config
+// set by upstream HTTP middleware into the job without the middleware
+// needing a reference to the job itself. It is a no-op if ctx carries no
+// such value.
+func (job *Job) ImportEnvFromContext(ctx context.Context) {
+	values, ok := ctx.Value(EnvContextKey).(map[string]string)
+	if !ok {
+		return
+	}
+	for key, value := range values {
+		job.Setenv(key, value)
+	}
+}
@@ -0,0 +1,20 @@
+package engine // import "github.com/docker/docker/engine"
+
+import (
+	"context"
+	"testing"
+)
+
+func TestImportEnvFromContext(t *testing.T) {
+	eng := New()
+	job := eng.Job("test")
+
+	ctx := context.WithValue(context.Background(), EnvContextKey, map[string]string{
+		"TENANT": "acme",
+	})
+	job.ImportEnvFromContext(ctx)
+
+	if got := job.Getenv("TENANT"); got != "acme" {
+		t.Fatalf("expected TENANT=acme, got %q", got)
+	}
+}
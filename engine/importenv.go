@@ -0,0 +1,56 @@
+package engine // import "github.com/docker/docker/engine"
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// ImportEnv sets the job's environment from src, a struct (or pointer to
+// one), using the same field-matching convention as UnknownEnvKeys (an
+// "env" tag, or the upper-cased field name). Nested struct fields are
+// flattened into dotted keys ("PARENT.CHILD") rather than collapsing into a
+// single JSON-encoded value, so every leaf remains readable via a plain
+// Getenv call.
+func (job *Job) ImportEnv(src interface{}) error {
+	v := reflect.ValueOf(src)
+	for v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+	return job.importEnvStruct("", v)
+}
+
+func (job *Job) importEnvStruct(prefix string, v reflect.Value) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		key := f.Tag.Get("env")
+		if key == "" {
+			key = strings.ToUpper(f.Name)
+		}
+		key = prefix + key
+		field := v.Field(i)
+
+		if field.Kind() == reflect.Struct {
+			if err := job.importEnvStruct(key+".", field); err != nil {
+				return err
+			}
+			continue
+		}
+
+		switch field.Kind() {
+		case reflect.String:
+			job.Setenv(key, field.String())
+		case reflect.Bool:
+			job.Setenv(key, strconv.FormatBool(field.Bool()))
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			job.Setenv(key, strconv.FormatInt(field.Int(), 10))
+		case reflect.Float32, reflect.Float64:
+			job.Setenv(key, strconv.FormatFloat(field.Float(), 'g', -1, 64))
+		default:
+			return fmt.Errorf("%s: unsupported field type %s", key, field.Kind())
+		}
+	}
+	return nil
+}
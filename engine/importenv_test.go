@@ -0,0 +1,29 @@
+package engine // import "github.com/docker/docker/engine"
+
+import "testing"
+
+type addressConfig struct {
+	City string
+}
+
+type personConfig struct {
+	Name    string
+	Address addressConfig
+}
+
+func TestImportEnvFlattensNestedStructs(t *testing.T) {
+	eng := New()
+	job := eng.Job("test")
+
+	cfg := personConfig{Name: "alice", Address: addressConfig{City: "nyc"}}
+	if err := job.ImportEnv(cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := job.Getenv("NAME"); got != "alice" {
+		t.Fatalf("expected NAME=alice, got %q", got)
+	}
+	if got := job.Getenv("ADDRESS.CITY"); got != "nyc" {
+		t.Fatalf("expected leaf readable via dotted key, got %q", got)
+	}
+}
@@ -0,0 +1,493 @@
+package engine // import "github.com/docker/docker/engine"
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"sync/atomic"
+	"text/template"
+	"time"
+)
+
+// Status codes returned by a Handler. StatusOK indicates success; any other
+// value is treated as a failure.
+const (
+	StatusOK        = "0"
+	StatusErr       = "1"
+	StatusNotFound  = "127"
+	StatusCancelled = "cancelled"
+	StatusTimeout   = "timeout"
+	// StatusMaxSpawnDepthExceeded is returned by a job created with Spawn
+	// once the engine's configured SetMaxSpawnDepth has been exceeded.
+	StatusMaxSpawnDepthExceeded = "max spawn depth exceeded"
+)
+
+// Handler is the signature implemented by functions registered with an
+// Engine. It receives the Job carrying its arguments and environment, and
+// returns a status code.
+type Handler func(*Job) string
+
+// Job represents a single named operation, with its arguments, environment,
+// and I/O streams. Jobs are created by calling Engine.Job and executed with
+// Run.
+type Job struct {
+	Eng     *Engine
+	Name    string
+	Args    []string
+	Stdin   io.Reader
+	Stdout  io.Writer
+	Stderr  io.Writer
+	env     Env
+	handler Handler
+	status  string
+	state   int32
+
+	outputFormat string
+	onExit       []func()
+	results      map[string]string
+	envOrder     []string
+	ctx          context.Context
+	fileCache    map[string]string
+	cancelFunc   context.CancelFunc
+	envDecls     []EnvDecl
+
+	attemptStatuses []string
+	envNamespace    string
+	lazyEnv         map[string]func() string
+
+	ownedStreams         []io.Closer
+	closeStreamsAfterRun bool
+	fallback             *Job
+	tail                 *tailBuffer
+	secrets              map[string]string
+	clock                Clock
+	templates            map[string]*template.Template
+	spawnCtx             context.Context
+	duration             time.Duration
+	redactPatterns       []*regexp.Regexp
+	envEncryptionKeys    map[string][]byte
+	envSizeWarn          *envSizeWarnConfig
+	secretProvider       SecretProvider
+	secretProviderCache  map[string]string
+	labels               map[string]string
+	successFunc          func(status string) bool
+	fields               map[string]interface{}
+	runErr               error
+}
+
+// Run executes the Job's handler and records its status. It returns an
+// error if no handler is registered for the Job's name, or if the handler
+// returns a non-success status.
+func (job *Job) Run() error {
+	atomic.StoreInt32(&job.state, int32(Running))
+	defer atomic.StoreInt32(&job.state, int32(Finished))
+	defer job.runExitHooks()
+	defer job.closeOwnedStreams()
+	defer job.recordCacheResult()
+	defer job.recordLastOutput()
+
+	start := job.getClock().Now()
+	defer func() {
+		job.duration = job.getClock().Now().Sub(start)
+		if job.Eng != nil {
+			job.Eng.checkSlowJob(job, job.duration)
+		}
+	}()
+
+	if job.Eng != nil {
+		job.Eng.inFlight.Add(1)
+		defer job.Eng.inFlight.Done()
+		job.Eng.trackRunning(job)
+		defer job.Eng.untrackRunning(job)
+	}
+
+	if job.Eng != nil && job.Eng.dryRun {
+		job.Eng.dryRunReport = append(job.Eng.dryRunReport, job.CommandLine())
+		job.status = job.successStatus()
+		return nil
+	}
+
+	if job.handler == nil {
+		job.status = StatusNotFound
+		return fmt.Errorf("%s: %w", job.Name, ErrHandlerNotFound)
+	}
+
+	if job.Eng != nil && job.Eng.baseCtx != nil {
+		done := make(chan string, 1)
+		go func() { done <- job.handler(job) }()
+		select {
+		case status := <-done:
+			job.status = status
+		case <-job.Eng.baseCtx.Done():
+			job.status = StatusCancelled
+			return fmt.Errorf("%s: %w", job.Name, ErrCancelled)
+		}
+	} else {
+		job.status = job.handler(job)
+	}
+	if !job.Succeeded() {
+		if job.runErr != nil {
+			return fmt.Errorf("%s: %w", job.Name, job.runErr)
+		}
+		return fmt.Errorf("%s: %s", job.Name, job.status)
+	}
+	return nil
+}
+
+// successStatus returns the status string that counts as success for this
+// job, honoring Engine.SetSuccessStatus if set.
+func (job *Job) successStatus() string {
+	if job.Eng != nil && job.Eng.successStatus != "" {
+		return job.Eng.successStatus
+	}
+	return StatusOK
+}
+
+// Succeeded reports whether the job's recorded status counts as success,
+// honoring SetSuccessFunc if set.
+func (job *Job) Succeeded() bool {
+	if job.successFunc != nil {
+		return job.successFunc(job.status)
+	}
+	return job.status == job.successStatus()
+}
+
+// SetSuccessFunc installs fn to decide whether the job's status counts as
+// success, for handlers that signal success with a convention other than
+// StatusOK. It overrides SetSuccessStatus for this job. Run consults this
+// through Succeeded to decide whether it returns an error.
+func (job *Job) SetSuccessFunc(fn func(status string) bool) {
+	job.successFunc = fn
+}
+
+// RunAsync runs the job in a new goroutine and returns a channel receiving
+// its result once Run returns.
+func (job *Job) RunAsync() <-chan error {
+	done := make(chan error, 1)
+	go func() {
+		done <- job.Run()
+	}()
+	return done
+}
+
+// Status returns the status recorded by the most recent call to Run.
+func (job *Job) Status() string {
+	return job.status
+}
+
+// String returns a human-readable representation of the job, for logging.
+// Arguments matching a pattern registered via AddRedactPattern are masked.
+// It only ever reads Name, Args and the job's redact patterns, so it is
+// safe to call on a bare
+// Job{Name: "x"} with a nil Eng, such as in a test that doesn't go through
+// Engine.Job.
+func (job *Job) String() string {
+	args := make([]string, len(job.Args))
+	for i, arg := range job.Args {
+		args[i] = job.redact(arg)
+	}
+	return fmt.Sprintf("%s%v", job.Name, args)
+}
+
+// Getenv returns the value of key in the job's environment, or "" if unset.
+// If the stored value is a file reference (see resolveEnvRef), the
+// referenced file's contents are returned instead; an unreadable file
+// yields "" and logs a warning. Use GetenvFileStrict to get the error
+// instead.
+func (job *Job) Getenv(key string) string {
+	value, err := job.resolveGetenv(key)
+	if err != nil {
+		job.Warnf("%s: %v\n", key, err)
+		return ""
+	}
+	return value
+}
+
+// resolveGetenv implements the resolution pipeline behind Getenv --
+// namespacing, lazy values, templates, secrets, alias fallback, job
+// chaining via SetFallback, decryption, file/secret references, and the
+// engine's EnvResolver hook -- returning an error instead of logging a
+// warning so strict variants such as GetenvFileStrict can share it. It is
+// the single counted entry point for EnvOpStats.Getenv; its alias and
+// fallback recursion calls resolveGetenvValue directly so that resolving
+// one caller-visible key through an alias or a fallback job doesn't count
+// as more than one Getenv call.
+func (job *Job) resolveGetenv(key string) (string, error) {
+	if job.Eng != nil {
+		atomic.AddInt64(&job.Eng.envOpStats.getenv, 1)
+	}
+	return job.resolveGetenvValue(key)
+}
+
+// resolveGetenvValue is resolveGetenv's uncounted implementation.
+func (job *Job) resolveGetenvValue(key string) (string, error) {
+	job.resolveLazy(key)
+	if value, ok := job.renderTemplate(key); ok {
+		return value, nil
+	}
+	if !job.env.Exists(job.namespaced(key)) {
+		if value, ok := job.getSecret(key); ok {
+			return value, nil
+		}
+		if job.Eng != nil {
+			for _, alias := range job.Eng.envAliases[key] {
+				if job.env.Exists(job.namespaced(alias)) {
+					return job.resolveGetenvValue(alias)
+				}
+			}
+		}
+		if job.fallback != nil {
+			return job.fallback.resolveGetenvValue(key)
+		}
+	}
+	raw := job.env.Get(job.namespaced(key))
+	if plain, ok := job.decryptEnvValue(key, raw); ok {
+		return plain, nil
+	}
+	resolved, err := job.resolveEnvRef(raw)
+	if err == nil {
+		resolved, err = job.resolveSecretRef(resolved)
+	}
+	if err != nil {
+		return "", err
+	}
+	if job.Eng != nil && job.Eng.envResolver != nil {
+		resolved = job.Eng.envResolver(job, key, resolved)
+	}
+	return resolved, nil
+}
+
+// Setenv sets key to value in the job's environment.
+func (job *Job) Setenv(key, value string) {
+	if job.Eng != nil {
+		atomic.AddInt64(&job.Eng.envOpStats.setenv, 1)
+	}
+	job.env.Set(job.namespaced(key), value)
+	job.checkEnvSizeWarn()
+}
+
+// GetenvBool returns the boolean value of key in the job's environment.
+func (job *Job) GetenvBool(key string) bool {
+	return job.env.GetBool(key)
+}
+
+// SetenvBool sets key to the string representation of value.
+func (job *Job) SetenvBool(key string, value bool) {
+	job.env.SetBool(key, value)
+}
+
+// GetenvClean returns the value of key with surrounding whitespace
+// trimmed, and a single layer of matching surrounding quotes (either "
+// or ') stripped if present. This avoids handlers doing ad hoc cleanup of
+// values sourced from places that pad or quote them, such as shell-style
+// env files.
+func (job *Job) GetenvClean(key string) string {
+	value := strings.TrimSpace(job.Getenv(key))
+	if len(value) >= 2 {
+		first, last := value[0], value[len(value)-1]
+		if (first == '"' && last == '"') || (first == '\'' && last == '\'') {
+			value = value[1 : len(value)-1]
+		}
+	}
+	return value
+}
+
+// GetenvBoolOk returns the boolean value of key in the job's environment,
+// and whether key was set at all. Callers that need to distinguish an
+// explicit false from an unset key, such as when merging with a parent's
+// defaults, should use this instead of GetenvBool.
+func (job *Job) GetenvBoolOk(key string) (value, ok bool) {
+	if !job.env.Exists(job.namespaced(key)) {
+		return false, false
+	}
+	return job.env.GetBool(job.namespaced(key)), true
+}
+
+// GetenvInt returns the integer value of key in the job's environment.
+func (job *Job) GetenvInt(key string) int {
+	return job.env.GetInt(key)
+}
+
+// SetenvInt sets key to the string representation of value.
+func (job *Job) SetenvInt(key string, value int) {
+	job.env.SetInt(key, value)
+}
+
+// GetenvList returns the value of key decoded as a JSON array of strings.
+func (job *Job) GetenvList(key string) []string {
+	return job.env.GetList(key)
+}
+
+// SetenvList stores value as a JSON-encoded array under key.
+func (job *Job) SetenvList(key string, value []string) {
+	job.env.SetList(key, value)
+}
+
+// Environ returns the job's environment as a map[string]string, forcing
+// evaluation of any lazy values registered via SetenvFunc.
+func (job *Job) Environ() map[string]string {
+	if job.Eng != nil {
+		atomic.AddInt64(&job.Eng.envOpStats.environ, 1)
+	}
+	job.resolveAllLazy()
+	return job.env.Map()
+}
+
+// EnvFilter returns the subset of the job's environment for which pred
+// returns true, given each key/value pair in turn.
+func (job *Job) EnvFilter(pred func(key, value string) bool) map[string]string {
+	filtered := make(map[string]string)
+	for key, value := range job.env.Map() {
+		if pred(key, value) {
+			filtered[key] = value
+		}
+	}
+	return filtered
+}
+
+// GetenvListAuto returns the value of key as a list of strings, accepting
+// either a JSON-encoded array (as produced by SetenvList) or a string
+// delimited by sep. JSON parsing is tried first; if the value is not valid
+// JSON, it is split on sep instead. This lets callers populate the same key
+// using whichever convention is convenient for them.
+func (job *Job) GetenvListAuto(key, sep string) []string {
+	raw := job.Getenv(key)
+	if raw == "" {
+		return nil
+	}
+	var l []string
+	if err := json.Unmarshal([]byte(raw), &l); err == nil {
+		return l
+	}
+	return strings.Split(raw, sep)
+}
+
+// OnExit registers fn to run after the job finishes, whether it succeeded,
+// failed, was cancelled, or timed out. Hooks run in the order they were
+// registered.
+func (job *Job) OnExit(fn func()) {
+	job.onExit = append(job.onExit, fn)
+}
+
+// runExitHooks invokes every hook registered with OnExit.
+func (job *Job) runExitHooks() {
+	for _, fn := range job.onExit {
+		fn()
+	}
+}
+
+// GetenvOrArg returns the value of key in the job's environment if set,
+// otherwise the argument at argIndex if it exists, otherwise "". This
+// bridges handlers that accept a setting as either an env var or a
+// positional argument.
+func (job *Job) GetenvOrArg(key string, argIndex int) string {
+	if value := job.Getenv(key); value != "" {
+		return value
+	}
+	if argIndex >= 0 && argIndex < len(job.Args) {
+		return job.Args[argIndex]
+	}
+	return ""
+}
+
+// SetenvChecked behaves like Setenv, but rejects keys containing '=', which
+// would otherwise be ambiguous when the pair is later parsed back out of
+// its "key=value" representation. Values may safely contain '=' -- Getenv
+// always returns everything after the first '=' in the stored pair.
+func (job *Job) SetenvChecked(key, value string) error {
+	if strings.Contains(key, "=") {
+		return fmt.Errorf("invalid env key %q: keys must not contain '='", key)
+	}
+	job.Setenv(key, value)
+	return nil
+}
+
+// GetenvEnum returns the value of key, constrained to allowed. If key is
+// absent, def is returned. If key is present but its value is not in
+// allowed, an error is returned. This centralizes validation of
+// enum-valued config (e.g. "always"/"never"/"on-failure").
+func (job *Job) GetenvEnum(key string, allowed []string, def string) (string, error) {
+	if !job.env.Exists(job.namespaced(key)) {
+		return def, nil
+	}
+	value := job.Getenv(key)
+	for _, a := range allowed {
+		if value == a {
+			return value, nil
+		}
+	}
+	return "", fmt.Errorf("%s: invalid value %q for %s (allowed: %s)", job.Name, value, key, strings.Join(allowed, ", "))
+}
+
+// AppendToList appends values to the JSON list stored under key (as read
+// by GetenvList), storing the result back via SetenvList. A missing or
+// empty key is treated as an empty list.
+func (job *Job) AppendToList(key string, values ...string) error {
+	current := job.GetenvList(key)
+	current = append(current, values...)
+	job.SetenvList(key, current)
+	return nil
+}
+
+// ResetStreams reinstalls fresh, discarding Stdout/Stderr and a nil Stdin,
+// leaving Name, Args and the environment untouched. It is useful when
+// reusing a job across multiple runs, such as in a retry loop, so that one
+// attempt's output doesn't bleed into the next.
+func (job *Job) ResetStreams() {
+	job.Stdin = nil
+	job.Stdout = io.Discard
+	job.Stderr = io.Discard
+}
+
+// InheritStreams copies from's Stdin, Stdout and Stderr onto job, so a
+// wrapper job can delegate its I/O to an inner one (or vice versa) without
+// repeating the three assignments by hand.
+func (job *Job) InheritStreams(from *Job) {
+	job.Stdin = from.Stdin
+	job.Stdout = from.Stdout
+	job.Stderr = from.Stderr
+}
+
+// RequireArgs checks that the job has between min and max arguments
+// (inclusive); max of -1 means unbounded. If the check fails, it sets the
+// job's status to StatusErr and returns a descriptive error; handlers
+// should return immediately in that case.
+func (job *Job) RequireArgs(min, max int) error {
+	n := len(job.Args)
+	if n < min || (max >= 0 && n > max) {
+		job.status = StatusErr
+		if max < 0 {
+			return fmt.Errorf("%s: expected at least %d argument(s), got %d", job.Name, min, n)
+		}
+		if min == max {
+			return fmt.Errorf("%s: expected %d argument(s), got %d", job.Name, min, n)
+		}
+		return fmt.Errorf("%s: expected between %d and %d argument(s), got %d", job.Name, min, max, n)
+	}
+	return nil
+}
+
+// StdinScanner returns a bufio.Scanner over the job's Stdin, for handlers
+// that want to process input line by line. If Stdin is nil, the scanner
+// reads from an empty reader rather than panicking.
+func (job *Job) StdinScanner() *bufio.Scanner {
+	if job.Stdin == nil {
+		return bufio.NewScanner(strings.NewReader(""))
+	}
+	return bufio.NewScanner(job.Stdin)
+}
+
+// RecordStdin wraps the job's Stdin in an io.TeeReader that copies
+// everything the handler reads into the returned buffer. It must be called
+// before Run. After the run, the buffer holds the full input the handler
+// consumed, regardless of how it chose to read it.
+func (job *Job) RecordStdin() *bytes.Buffer {
+	record := new(bytes.Buffer)
+	job.Stdin = io.TeeReader(job.Stdin, record)
+	return record
+}
@@ -0,0 +1,189 @@
+package engine // import "github.com/docker/docker/engine"
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestGetenvListAuto(t *testing.T) {
+	eng := New()
+	job := eng.Job("test")
+
+	job.Setenv("JSON", `["a","b","c"]`)
+	if l := job.GetenvListAuto("JSON", ":"); !reflect.DeepEqual(l, []string{"a", "b", "c"}) {
+		t.Fatalf("expected JSON array to be parsed, got %#v", l)
+	}
+
+	job.Setenv("DELIM", "a:b:c")
+	if l := job.GetenvListAuto("DELIM", ":"); !reflect.DeepEqual(l, []string{"a", "b", "c"}) {
+		t.Fatalf("expected delimited value to be split, got %#v", l)
+	}
+
+	if l := job.GetenvListAuto("MISSING", ":"); l != nil {
+		t.Fatalf("expected nil for missing key, got %#v", l)
+	}
+}
+
+func TestEnvFilter(t *testing.T) {
+	eng := New()
+	job := eng.Job("test")
+	job.Setenv("API_KEY", "plaintext")
+	job.Setenv("SECRET_TOKEN", "shh")
+	job.Setenv("PORT", "8080")
+
+	filtered := job.EnvFilter(func(key, value string) bool {
+		return !strings.Contains(key, "SECRET")
+	})
+	if _, ok := filtered["SECRET_TOKEN"]; ok {
+		t.Fatal("expected SECRET_TOKEN to be excluded")
+	}
+	if filtered["PORT"] != "8080" || filtered["API_KEY"] != "plaintext" {
+		t.Fatalf("expected non-secret keys to be present, got %#v", filtered)
+	}
+}
+
+func TestGetenvOrArg(t *testing.T) {
+	eng := New()
+
+	withEnv := eng.Job("test", "fromarg")
+	withEnv.Setenv("NAME", "fromenv")
+	if got := withEnv.GetenvOrArg("NAME", 0); got != "fromenv" {
+		t.Fatalf("expected env value to win, got %q", got)
+	}
+
+	withArg := eng.Job("test", "fromarg")
+	if got := withArg.GetenvOrArg("NAME", 0); got != "fromarg" {
+		t.Fatalf("expected arg fallback, got %q", got)
+	}
+
+	withNeither := eng.Job("test")
+	if got := withNeither.GetenvOrArg("NAME", 0); got != "" {
+		t.Fatalf("expected empty string, got %q", got)
+	}
+}
+
+func TestRequireArgs(t *testing.T) {
+	eng := New()
+
+	if err := eng.Job("test", "a").RequireArgs(2, 3); err == nil {
+		t.Fatal("expected an error for too few args")
+	}
+	if err := eng.Job("test", "a", "b").RequireArgs(2, 3); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := eng.Job("test", "a", "b", "c", "d").RequireArgs(2, 3); err == nil {
+		t.Fatal("expected an error for too many args")
+	}
+	if err := eng.Job("test", "a", "b", "c", "d").RequireArgs(1, -1); err != nil {
+		t.Fatalf("expected no error for unbounded max, got %v", err)
+	}
+}
+
+func TestAppendToList(t *testing.T) {
+	eng := New()
+
+	existing := eng.Job("test")
+	existing.SetenvList("TAGS", []string{"a", "b"})
+	if err := existing.AppendToList("TAGS", "c"); err != nil {
+		t.Fatal(err)
+	}
+	if got := existing.GetenvList("TAGS"); !reflect.DeepEqual(got, []string{"a", "b", "c"}) {
+		t.Fatalf("expected [a b c], got %#v", got)
+	}
+
+	absent := eng.Job("test")
+	if err := absent.AppendToList("TAGS", "x"); err != nil {
+		t.Fatal(err)
+	}
+	if got := absent.GetenvList("TAGS"); !reflect.DeepEqual(got, []string{"x"}) {
+		t.Fatalf("expected [x], got %#v", got)
+	}
+}
+
+func TestGetenvEnum(t *testing.T) {
+	eng := New()
+	allowed := []string{"always", "never", "on-failure"}
+
+	present := eng.Job("test")
+	present.Setenv("RESTART", "on-failure")
+	if v, err := present.GetenvEnum("RESTART", allowed, "never"); err != nil || v != "on-failure" {
+		t.Fatalf("expected on-failure, got %q, %v", v, err)
+	}
+
+	absent := eng.Job("test")
+	if v, err := absent.GetenvEnum("RESTART", allowed, "never"); err != nil || v != "never" {
+		t.Fatalf("expected default never, got %q, %v", v, err)
+	}
+
+	invalid := eng.Job("test")
+	invalid.Setenv("RESTART", "sometimes")
+	if _, err := invalid.GetenvEnum("RESTART", allowed, "never"); err == nil {
+		t.Fatal("expected an error for an invalid value")
+	}
+}
+
+func TestSetenvValueContainingEquals(t *testing.T) {
+	eng := New()
+	job := eng.Job("test")
+	job.Setenv("A", "b=c")
+	if got := job.Getenv("A"); got != "b=c" {
+		t.Fatalf("expected value containing '=' to round-trip intact, got %q", got)
+	}
+}
+
+func TestSetenvCheckedRejectsEqualsInKey(t *testing.T) {
+	eng := New()
+	job := eng.Job("test")
+	if err := job.SetenvChecked("BAD=KEY", "value"); err == nil {
+		t.Fatal("expected an error for a key containing '='")
+	}
+	if err := job.SetenvChecked("GOOD_KEY", "value"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestStringNilEngineSafe(t *testing.T) {
+	job := &Job{Name: "x", Args: []string{"a", "b"}}
+	got := job.String()
+	if got != "x[a b]" {
+		t.Fatalf("expected a readable string for a bare job, got %q", got)
+	}
+}
+
+func TestGetenvClean(t *testing.T) {
+	eng := New()
+	job := eng.Job("test")
+
+	job.Setenv("PADDED", "  hello  ")
+	if got := job.GetenvClean("PADDED"); got != "hello" {
+		t.Fatalf("expected %q, got %q", "hello", got)
+	}
+
+	job.Setenv("QUOTED", `"hello"`)
+	if got := job.GetenvClean("QUOTED"); got != "hello" {
+		t.Fatalf("expected %q, got %q", "hello", got)
+	}
+
+	job.Setenv("SINGLEQUOTED", "  'hello'  ")
+	if got := job.GetenvClean("SINGLEQUOTED"); got != "hello" {
+		t.Fatalf("expected %q, got %q", "hello", got)
+	}
+}
+
+func TestInheritStreams(t *testing.T) {
+	eng := New()
+	inner := eng.Job("inner")
+	var out bytes.Buffer
+	inner.Stdout = &out
+
+	wrapper := eng.Job("wrapper")
+	wrapper.InheritStreams(inner)
+	fmt.Fprint(wrapper.Stdout, "hello")
+
+	if got := out.String(); got != "hello" {
+		t.Fatalf("expected output to reach inner job's buffer, got %q", got)
+	}
+}
@@ -0,0 +1,54 @@
+package engine // import "github.com/docker/docker/engine"
+
+// cachedJobResult is a prior run's outcome, keyed by Job.Hash, recorded
+// once EnableCache is active.
+type cachedJobResult struct {
+	status string
+	out    []byte
+}
+
+// EnableCache turns on recording of each job's outcome, keyed by its Hash,
+// for later retrieval via CachedResult. It has no effect on jobs already
+// run before it was called.
+func (eng *Engine) EnableCache() {
+	eng.cacheMu.Lock()
+	defer eng.cacheMu.Unlock()
+	eng.cacheEnabled = true
+	if eng.cache == nil {
+		eng.cache = make(map[string]cachedJobResult)
+	}
+}
+
+// recordCacheResult stores job's outcome under its Hash, if caching is
+// enabled on its Engine.
+func (job *Job) recordCacheResult() {
+	if job.Eng == nil {
+		return
+	}
+	job.Eng.cacheMu.Lock()
+	defer job.Eng.cacheMu.Unlock()
+	if !job.Eng.cacheEnabled {
+		return
+	}
+	var out []byte
+	if captured, ok := job.Stdout.(*CapturedOutput); ok {
+		out = []byte(captured.String())
+	}
+	job.Eng.cache[job.Hash()] = cachedJobResult{status: job.status, out: out}
+}
+
+// CachedResult reports the cached outcome of a prior run of an equivalent
+// job (same Hash), as recorded while Engine.EnableCache was active, without
+// running the handler. Callers decide whether to skip the run on a hit.
+func (job *Job) CachedResult() (status string, out []byte, ok bool) {
+	if job.Eng == nil {
+		return "", nil, false
+	}
+	job.Eng.cacheMu.Lock()
+	defer job.Eng.cacheMu.Unlock()
+	result, ok := job.Eng.cache[job.Hash()]
+	if !ok {
+		return "", nil, false
+	}
+	return result.status, result.out, true
+}
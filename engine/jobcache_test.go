@@ -0,0 +1,32 @@
+package engine // import "github.com/docker/docker/engine"
+
+import "testing"
+
+func TestCachedResultHitAndMiss(t *testing.T) {
+	eng := New()
+	eng.EnableCache()
+	eng.Register("greet", func(job *Job) string {
+		job.CaptureStdout()
+		job.Stdout.Write([]byte("hello"))
+		return StatusOK
+	})
+
+	job := eng.Job("greet")
+	if err := job.Run(); err != nil {
+		t.Fatal(err)
+	}
+
+	again := eng.Job("greet")
+	status, out, ok := again.CachedResult()
+	if !ok {
+		t.Fatal("expected a cache hit for an equivalent job")
+	}
+	if status != StatusOK || string(out) != "hello" {
+		t.Fatalf("unexpected cached result: status=%q out=%q", status, out)
+	}
+
+	fresh := eng.Job("greet", "different-arg")
+	if _, _, ok := fresh.CachedResult(); ok {
+		t.Fatal("expected a cache miss for a job with a different hash")
+	}
+}
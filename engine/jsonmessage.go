@@ -0,0 +1,35 @@
+package engine // import "github.com/docker/docker/engine"
+
+import "encoding/json"
+
+// jsonMessageProgress mirrors the progressDetail field of Docker's
+// JSONMessage wire format.
+type jsonMessageProgress struct {
+	Current int64 `json:"current"`
+	Total   int64 `json:"total"`
+}
+
+// jsonMessage mirrors the subset of Docker's JSONMessage wire format that
+// JSONMessage emits, so jobs can drive existing docker pull/push display
+// code without it needing to know about the engine package.
+type jsonMessage struct {
+	Status         string              `json:"status"`
+	ID             string              `json:"id,omitempty"`
+	ProgressDetail jsonMessageProgress `json:"progressDetail"`
+	Stream         string              `json:"stream,omitempty"`
+}
+
+// JSONMessage writes a single newline-delimited JSON object to Stdout in
+// Docker's JSONMessage shape, for compatibility with existing pull/push
+// progress renderers.
+func (job *Job) JSONMessage(status, id string, current, total int64) {
+	b, err := json.Marshal(jsonMessage{
+		Status:         status,
+		ID:             id,
+		ProgressDetail: jsonMessageProgress{Current: current, Total: total},
+	})
+	if err != nil {
+		panic(err) // Cannot happen: jsonMessage is built from known-good types
+	}
+	job.Stdout.Write(append(b, '\n'))
+}
@@ -0,0 +1,31 @@
+package engine // import "github.com/docker/docker/engine"
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestJSONMessageShape(t *testing.T) {
+	eng := New()
+	job := eng.Job("test")
+	out := new(bytes.Buffer)
+	job.Stdout = out
+
+	job.JSONMessage("Downloading", "layer1", 50, 100)
+
+	var got struct {
+		Status         string `json:"status"`
+		ID             string `json:"id"`
+		ProgressDetail struct {
+			Current int64 `json:"current"`
+			Total   int64 `json:"total"`
+		} `json:"progressDetail"`
+	}
+	if err := json.Unmarshal(bytes.TrimSpace(out.Bytes()), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Status != "Downloading" || got.ID != "layer1" || got.ProgressDetail.Current != 50 || got.ProgressDetail.Total != 100 {
+		t.Fatalf("unexpected message: %+v", got)
+	}
+}
@@ -0,0 +1,18 @@
+package engine // import "github.com/docker/docker/engine"
+
+// SetLabel attaches key=value metadata to the job, for grouping and
+// targeting jobs after creation (see Engine.CancelByLabel). Labels play no
+// part in dispatch or env resolution; they're purely descriptive.
+func (job *Job) SetLabel(key, value string) {
+	if job.labels == nil {
+		job.labels = make(map[string]string)
+	}
+	job.labels[key] = value
+}
+
+// Label returns the value of key among the job's labels, and whether it
+// was set.
+func (job *Job) Label(key string) (string, bool) {
+	value, ok := job.labels[key]
+	return value, ok
+}
@@ -0,0 +1,43 @@
+package engine // import "github.com/docker/docker/engine"
+
+// EnableOutputRetention turns on recording of each job's captured stdout,
+// keyed by job name, for later retrieval via LastOutput. Only the most
+// recent run of each name is kept, bounding memory to one entry per
+// distinct job name regardless of how many times it runs.
+func (eng *Engine) EnableOutputRetention() {
+	eng.outputMu.Lock()
+	defer eng.outputMu.Unlock()
+	eng.retainOutputs = true
+	if eng.lastOutputs == nil {
+		eng.lastOutputs = make(map[string][]byte)
+	}
+}
+
+// recordLastOutput stores job's captured stdout under its Name, if output
+// retention is enabled and the job's Stdout was captured via CaptureStdout.
+func (job *Job) recordLastOutput() {
+	if job.Eng == nil {
+		return
+	}
+	captured, ok := job.Stdout.(*CapturedOutput)
+	if !ok {
+		return
+	}
+	job.Eng.outputMu.Lock()
+	defer job.Eng.outputMu.Unlock()
+	if !job.Eng.retainOutputs {
+		return
+	}
+	job.Eng.lastOutputs[job.Name] = []byte(captured.String())
+}
+
+// LastOutput returns the captured stdout of the most recent run of the job
+// named name, and whether one was recorded. It requires that
+// EnableOutputRetention was called and the run captured its output via
+// CaptureStdout; otherwise ok is false.
+func (eng *Engine) LastOutput(name string) ([]byte, bool) {
+	eng.outputMu.Lock()
+	defer eng.outputMu.Unlock()
+	out, ok := eng.lastOutputs[name]
+	return out, ok
+}
@@ -0,0 +1,26 @@
+package engine // import "github.com/docker/docker/engine"
+
+import "testing"
+
+func TestLastOutputRetrievableAfterRun(t *testing.T) {
+	eng := New()
+	eng.EnableOutputRetention()
+	eng.Register("greet", func(job *Job) string {
+		job.CaptureStdout()
+		job.Stdout.Write([]byte("hi there"))
+		return StatusOK
+	})
+
+	if err := eng.Job("greet").Run(); err != nil {
+		t.Fatal(err)
+	}
+
+	out, ok := eng.LastOutput("greet")
+	if !ok || string(out) != "hi there" {
+		t.Fatalf("expected retained output %q, got %q (ok=%v)", "hi there", out, ok)
+	}
+
+	if _, ok := eng.LastOutput("never-run"); ok {
+		t.Fatal("expected no output for a name that never ran")
+	}
+}
@@ -0,0 +1,31 @@
+package engine // import "github.com/docker/docker/engine"
+
+// SetenvFunc registers fn as the value of key, to be computed at most once,
+// the first time Getenv(key) is called, and cached from then on. This
+// avoids paying for expensive config that a given run may never actually
+// read. Environ forces evaluation of every pending lazy key, since it must
+// return a complete snapshot.
+func (job *Job) SetenvFunc(key string, fn func() string) {
+	if job.lazyEnv == nil {
+		job.lazyEnv = make(map[string]func() string)
+	}
+	job.lazyEnv[key] = fn
+}
+
+// resolveLazy computes and caches the lazy value for key, if one is
+// pending, removing it from the pending set.
+func (job *Job) resolveLazy(key string) {
+	fn, ok := job.lazyEnv[key]
+	if !ok {
+		return
+	}
+	delete(job.lazyEnv, key)
+	job.Setenv(key, fn())
+}
+
+// resolveAllLazy forces evaluation of every pending lazy env key.
+func (job *Job) resolveAllLazy() {
+	for key := range job.lazyEnv {
+		job.resolveLazy(key)
+	}
+}
@@ -0,0 +1,24 @@
+package engine // import "github.com/docker/docker/engine"
+
+import "testing"
+
+func TestSetenvFuncComputesOnceAndOnlyWhenRead(t *testing.T) {
+	eng := New()
+	job := eng.Job("test")
+	calls := 0
+	job.SetenvFunc("EXPENSIVE", func() string {
+		calls++
+		return "computed"
+	})
+
+	if calls != 0 {
+		t.Fatalf("expected fn not to run until read, got %d calls", calls)
+	}
+	if got := job.Getenv("EXPENSIVE"); got != "computed" {
+		t.Fatalf("expected %q, got %q", "computed", got)
+	}
+	job.Getenv("EXPENSIVE")
+	if calls != 1 {
+		t.Fatalf("expected fn to run exactly once, got %d calls", calls)
+	}
+}
@@ -0,0 +1,25 @@
+package engine // import "github.com/docker/docker/engine"
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestEngineLogf(t *testing.T) {
+	eng := New()
+	var buf bytes.Buffer
+	eng.Logger = &buf
+
+	eng.Logf("starting %s\n", "up")
+
+	job := eng.Job("noop")
+	var jobOut bytes.Buffer
+	job.Stdout = &jobOut
+
+	if buf.String() != "starting up\n" {
+		t.Fatalf("expected engine log to contain the message, got %q", buf.String())
+	}
+	if jobOut.Len() != 0 {
+		t.Fatalf("expected job stream to be untouched, got %q", jobOut.String())
+	}
+}
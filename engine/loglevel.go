@@ -0,0 +1,69 @@
+package engine // import "github.com/docker/docker/engine"
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// logLevelOrder ranks the supported LOG_LEVEL values from most to least
+// verbose.
+var logLevelOrder = map[string]int{"debug": 0, "info": 1, "warn": 2, "error": 3}
+
+// logLevel returns the job's configured log level threshold, read from the
+// LOG_LEVEL env key, defaulting to "info" when unset or unrecognized.
+func (job *Job) logLevel() string {
+	if lvl := job.Getenv("LOG_LEVEL"); lvl != "" {
+		if _, ok := logLevelOrder[lvl]; ok {
+			return lvl
+		}
+	}
+	return "info"
+}
+
+// logf writes a leveled message to Stderr if level meets the job's
+// configured LOG_LEVEL threshold. When fields have been attached via
+// WithField, the message is emitted as a JSON object merging "level",
+// "msg" and the fields instead of the plain formatted text.
+func (job *Job) logf(level, format string, args ...interface{}) {
+	if logLevelOrder[level] < logLevelOrder[job.logLevel()] {
+		return
+	}
+	message := fmt.Sprintf(format, args...)
+	if len(job.fields) == 0 {
+		fmt.Fprint(job.Stderr, message)
+		return
+	}
+	line := make(map[string]interface{}, len(job.fields)+2)
+	for k, v := range job.fields {
+		line[k] = v
+	}
+	line["level"] = level
+	line["msg"] = message
+	encoded, err := json.Marshal(line)
+	if err != nil {
+		fmt.Fprint(job.Stderr, message)
+		return
+	}
+	fmt.Fprintln(job.Stderr, string(encoded))
+}
+
+// Debugf logs a debug-level message, suppressed unless LOG_LEVEL is "debug".
+func (job *Job) Debugf(format string, args ...interface{}) {
+	job.logf("debug", format, args...)
+}
+
+// Infof logs an info-level message, suppressed when LOG_LEVEL is "warn" or
+// "error".
+func (job *Job) Infof(format string, args ...interface{}) {
+	job.logf("info", format, args...)
+}
+
+// Warnf logs a warn-level message, suppressed when LOG_LEVEL is "error".
+func (job *Job) Warnf(format string, args ...interface{}) {
+	job.logf("warn", format, args...)
+}
+
+// Errorf logs an error-level message. It is never suppressed by LOG_LEVEL.
+func (job *Job) Errorf(format string, args ...interface{}) {
+	job.logf("error", format, args...)
+}
@@ -0,0 +1,24 @@
+package engine // import "github.com/docker/docker/engine"
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestLogLevelSuppressesInfof(t *testing.T) {
+	eng := New()
+	job := eng.Job("test")
+	var stderr bytes.Buffer
+	job.Stderr = &stderr
+	job.Setenv("LOG_LEVEL", "error")
+
+	job.Infof("should not appear")
+	if stderr.Len() != 0 {
+		t.Fatalf("expected Infof to be suppressed, got %q", stderr.String())
+	}
+
+	job.Errorf("should appear")
+	if stderr.Len() == 0 {
+		t.Fatal("expected Errorf to always be emitted")
+	}
+}
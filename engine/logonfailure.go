@@ -0,0 +1,25 @@
+package engine // import "github.com/docker/docker/engine"
+
+import "bytes"
+
+// SetLogOnFailureOnly buffers everything written to Stdout and Stderr
+// (including Debugf/Infof/Warnf/Errorf, which write to Stderr) during the
+// job's run. The buffered output is flushed to the real destinations only
+// if the job fails; on success it's discarded, keeping healthy runs quiet.
+// It must be called before Run.
+func (job *Job) SetLogOnFailureOnly(enable bool) {
+	if !enable {
+		return
+	}
+	realStdout, realStderr := job.Stdout, job.Stderr
+	stdoutBuf := new(bytes.Buffer)
+	stderrBuf := new(bytes.Buffer)
+	job.Stdout = stdoutBuf
+	job.Stderr = stderrBuf
+	job.OnExit(func() {
+		if !job.Succeeded() {
+			realStdout.Write(stdoutBuf.Bytes())
+			realStderr.Write(stderrBuf.Bytes())
+		}
+	})
+}
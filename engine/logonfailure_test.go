@@ -0,0 +1,42 @@
+package engine // import "github.com/docker/docker/engine"
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestSetLogOnFailureOnlySuppressesOnSuccess(t *testing.T) {
+	eng := New()
+	eng.Register("ok", func(job *Job) string {
+		job.Stdout.Write([]byte("noisy success"))
+		return StatusOK
+	})
+	job := eng.Job("ok")
+	out := new(bytes.Buffer)
+	job.Stdout = out
+	job.SetLogOnFailureOnly(true)
+
+	if err := job.Run(); err != nil {
+		t.Fatal(err)
+	}
+	if out.Len() != 0 {
+		t.Fatalf("expected no output on success, got %q", out.String())
+	}
+}
+
+func TestSetLogOnFailureOnlyFlushesOnFailure(t *testing.T) {
+	eng := New()
+	eng.Register("fails", func(job *Job) string {
+		job.Stdout.Write([]byte("diagnostic info"))
+		return StatusErr
+	})
+	job := eng.Job("fails")
+	out := new(bytes.Buffer)
+	job.Stdout = out
+	job.SetLogOnFailureOnly(true)
+
+	job.Run()
+	if out.String() != "diagnostic info" {
+		t.Fatalf("expected buffered output flushed on failure, got %q", out.String())
+	}
+}
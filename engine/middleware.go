@@ -0,0 +1,34 @@
+package engine // import "github.com/docker/docker/engine"
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/containerd/log"
+)
+
+// TimeoutFromHeader returns middleware that reads the "X-Job-Timeout"
+// header from each request and applies it as a context timeout before
+// calling next, so that a handler running the job via RunContext (such as
+// Engine.ServeHTTP) is bounded by it. A missing or malformed header leaves
+// the request's context unchanged; malformed headers are logged as a
+// warning rather than rejected.
+func TimeoutFromHeader(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		raw := r.Header.Get("X-Job-Timeout")
+		if raw == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			log.G(r.Context()).Warnf("ignoring invalid X-Job-Timeout header %q: %v", raw, err)
+			next.ServeHTTP(w, r)
+			return
+		}
+		ctx, cancel := context.WithTimeout(r.Context(), d)
+		defer cancel()
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
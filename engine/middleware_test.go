@@ -0,0 +1,42 @@
+package engine // import "github.com/docker/docker/engine"
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTimeoutFromHeader(t *testing.T) {
+	eng := New()
+	if err := eng.Register("slow", func(job *Job) string {
+		time.Sleep(time.Hour)
+		return StatusOK
+	}); err != nil {
+		t.Fatal(err)
+	}
+	srv := httptest.NewServer(TimeoutFromHeader(eng))
+	defer srv.Close()
+
+	req, err := http.NewRequest(http.MethodPost, srv.URL, strings.NewReader(`{"Name":"slow"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("X-Job-Timeout", "50ms")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	// Trailers are only populated once the body has been read to EOF.
+	io.Copy(io.Discard, resp.Body)
+
+	status := resp.Trailer.Get("X-Job-Status")
+	if status != StatusCancelled {
+		t.Fatalf("expected status %q, got %q", StatusCancelled, status)
+	}
+}
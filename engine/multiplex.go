@@ -0,0 +1,17 @@
+package engine // import "github.com/docker/docker/engine"
+
+import (
+	"io"
+
+	"github.com/docker/docker/pkg/stdcopy"
+)
+
+// SetMultiplexedOutput frames the job's Stdout and Stderr writes onto w
+// using the docker attach protocol: each write is prefixed with an 8-byte
+// stdcopy header identifying the stream (Stdout or Stderr) and its length.
+// This makes the job's output consumable by clients that expect the
+// multiplexed format, such as docker attach.
+func (job *Job) SetMultiplexedOutput(w io.Writer) {
+	job.Stdout = stdcopy.NewStdWriter(w, stdcopy.Stdout)
+	job.Stderr = stdcopy.NewStdWriter(w, stdcopy.Stderr)
+}
@@ -0,0 +1,35 @@
+package engine // import "github.com/docker/docker/engine"
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/docker/docker/pkg/stdcopy"
+)
+
+func TestSetMultiplexedOutput(t *testing.T) {
+	eng := New()
+	job := eng.Job("test")
+	var combined bytes.Buffer
+	job.SetMultiplexedOutput(&combined)
+
+	job.Stdout.Write([]byte("out"))
+	job.Stderr.Write([]byte("err"))
+
+	frame := combined.Bytes()
+
+	// First frame: stdout.
+	if frame[0] != byte(stdcopy.Stdout) {
+		t.Fatalf("expected stdout stream byte, got %d", frame[0])
+	}
+	firstLen := int(frame[4])<<24 | int(frame[5])<<16 | int(frame[6])<<8 | int(frame[7])
+	if firstLen != len("out") {
+		t.Fatalf("expected length %d, got %d", len("out"), firstLen)
+	}
+
+	// Second frame follows right after the first payload.
+	second := frame[8+firstLen:]
+	if second[0] != byte(stdcopy.Stderr) {
+		t.Fatalf("expected stderr stream byte, got %d", second[0])
+	}
+}
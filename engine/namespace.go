@@ -0,0 +1,16 @@
+package engine // import "github.com/docker/docker/engine"
+
+// SetEnvNamespace configures prefix to be transparently prepended to every
+// key passed to Getenv/Setenv, so handlers can be written against plain
+// names while their config actually lives under a shared prefix (e.g.
+// "APP_") in a larger environment. The empty string (the default) disables
+// namespacing and preserves the unprefixed behavior.
+func (job *Job) SetEnvNamespace(prefix string) {
+	job.envNamespace = prefix
+}
+
+// namespaced returns key with the job's namespace prefix applied, if one is
+// set.
+func (job *Job) namespaced(key string) string {
+	return job.envNamespace + key
+}
@@ -0,0 +1,17 @@
+package engine // import "github.com/docker/docker/engine"
+
+import "testing"
+
+func TestSetEnvNamespace(t *testing.T) {
+	eng := New()
+	job := eng.Job("test")
+	job.SetEnvNamespace("APP_")
+
+	job.Setenv("PORT", "8080")
+	if got := job.Environ()["APP_PORT"]; got != "8080" {
+		t.Fatalf("expected underlying key APP_PORT to be set, got %q", got)
+	}
+	if got := job.Getenv("PORT"); got != "8080" {
+		t.Fatalf("expected namespaced Getenv to find it, got %q", got)
+	}
+}
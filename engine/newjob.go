@@ -0,0 +1,44 @@
+package engine // import "github.com/docker/docker/engine"
+
+import (
+	"os"
+	"strings"
+)
+
+// JobOption configures a Job constructed by NewJob.
+type JobOption func(*Job)
+
+// NewJob creates a job named name on eng and applies opts in order. Options
+// are plain assignments, so passing WithOSEnv before WithEnv lets explicit
+// job config override inherited host config, the common "inherit then
+// override" startup pattern.
+func NewJob(eng *Engine, name string, opts ...JobOption) *Job {
+	job := eng.Job(name)
+	for _, opt := range opts {
+		opt(job)
+	}
+	return job
+}
+
+// WithEnv sets key to value on the constructed job.
+func WithEnv(key, value string) JobOption {
+	return func(job *Job) {
+		job.Setenv(key, value)
+	}
+}
+
+// WithOSEnv seeds the job's environment from the process's OS environment,
+// restricted to variables whose name starts with prefix (or every variable,
+// if prefix is ""), with prefix stripped from the resulting key. Use it
+// before any WithEnv options so they can override the inherited values.
+func WithOSEnv(prefix string) JobOption {
+	return func(job *Job) {
+		for _, kv := range os.Environ() {
+			key, value, ok := strings.Cut(kv, "=")
+			if !ok || !strings.HasPrefix(key, prefix) {
+				continue
+			}
+			job.Setenv(strings.TrimPrefix(key, prefix), value)
+		}
+	}
+}
@@ -0,0 +1,25 @@
+package engine // import "github.com/docker/docker/engine"
+
+import (
+	"os"
+	"testing"
+)
+
+func TestWithOSEnvThenWithEnvOverrides(t *testing.T) {
+	os.Setenv("ENGINE_TEST_HOST_VAR", "from-host")
+	defer os.Unsetenv("ENGINE_TEST_HOST_VAR")
+
+	eng := New()
+	job := NewJob(eng, "test", WithOSEnv("ENGINE_TEST_"))
+	if got := job.Getenv("HOST_VAR"); got != "from-host" {
+		t.Fatalf("expected host value to be inherited, got %q", got)
+	}
+
+	overridden := NewJob(eng, "test",
+		WithOSEnv("ENGINE_TEST_"),
+		WithEnv("HOST_VAR", "from-job"),
+	)
+	if got := overridden.Getenv("HOST_VAR"); got != "from-job" {
+		t.Fatalf("expected job config to override host value, got %q", got)
+	}
+}
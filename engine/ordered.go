@@ -0,0 +1,87 @@
+package engine // import "github.com/docker/docker/engine"
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// DecodeEnvOrdered behaves like DecodeEnv, but additionally records the
+// order in which keys appeared in the source JSON object, via
+// json.Decoder's token scanning. The recorded order is used by
+// EncodeEnvOrdered to reproduce it, which matters when re-encoding for
+// signature verification.
+func (job *Job) DecodeEnvOrdered(src io.Reader) error {
+	dec := json.NewDecoder(src)
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '{' {
+		return fmt.Errorf("expected a JSON object")
+	}
+	var order []string
+	for dec.More() {
+		keyTok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		key, ok := keyTok.(string)
+		if !ok {
+			return fmt.Errorf("expected a string key, got %v", keyTok)
+		}
+		var value string
+		if err := dec.Decode(&value); err != nil {
+			return err
+		}
+		job.Setenv(key, value)
+		order = append(order, key)
+	}
+	if _, err := dec.Token(); err != nil { // consume closing '}'
+		return err
+	}
+	job.envOrder = order
+	return nil
+}
+
+// EncodeEnvOrdered writes the job's environment to w as a JSON object,
+// using the key order recorded by DecodeEnvOrdered when available. Without
+// a recorded order, it falls back to Environ's arbitrary map order.
+func (job *Job) EncodeEnvOrdered(w io.Writer) error {
+	keys := job.envOrder
+	if keys == nil {
+		for k := range job.Environ() {
+			keys = append(keys, k)
+		}
+	}
+
+	if _, err := io.WriteString(w, "{"); err != nil {
+		return err
+	}
+	for i, k := range keys {
+		if i > 0 {
+			if _, err := io.WriteString(w, ","); err != nil {
+				return err
+			}
+		}
+		kb, err := json.Marshal(k)
+		if err != nil {
+			return err
+		}
+		if _, err := w.Write(kb); err != nil {
+			return err
+		}
+		if _, err := io.WriteString(w, ":"); err != nil {
+			return err
+		}
+		vb, err := json.Marshal(job.Getenv(k))
+		if err != nil {
+			return err
+		}
+		if _, err := w.Write(vb); err != nil {
+			return err
+		}
+	}
+	_, err := io.WriteString(w, "}")
+	return err
+}
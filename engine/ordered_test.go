@@ -0,0 +1,25 @@
+package engine // import "github.com/docker/docker/engine"
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestDecodeEncodeEnvOrdered(t *testing.T) {
+	eng := New()
+	job := eng.Job("test")
+
+	src := `{"c":"3","a":"1","b":"2"}`
+	if err := job.DecodeEnvOrdered(strings.NewReader(src)); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	if err := job.EncodeEnvOrdered(&out); err != nil {
+		t.Fatal(err)
+	}
+	if out.String() != src {
+		t.Fatalf("expected round-trip to preserve key order, got %q, want %q", out.String(), src)
+	}
+}
@@ -0,0 +1,28 @@
+package engine // import "github.com/docker/docker/engine"
+
+// WithOverrides returns a new Engine that resolves names in overrides
+// directly to the given handlers, falling back to the parent's Registry for
+// any other name. The parent Engine is never modified, so overrides can be
+// scoped to a single request without affecting other callers sharing the
+// parent.
+func (eng *Engine) WithOverrides(overrides map[string]func(*Job) string) *Engine {
+	derived := &Engine{
+		registry:      eng.registry,
+		successStatus: eng.successStatus,
+		Logger:        eng.Logger,
+		overrides:     make(map[string]Handler, len(overrides)),
+	}
+	for name, handler := range overrides {
+		derived.overrides[name] = Handler(handler)
+	}
+	return derived
+}
+
+// lookup resolves name to a Handler, consulting the engine's overrides
+// before falling back to its Registry.
+func (eng *Engine) lookup(name string) (Handler, bool) {
+	if handler, ok := eng.overrides[name]; ok {
+		return handler, true
+	}
+	return eng.registry.Lookup(name)
+}
@@ -0,0 +1,56 @@
+package engine // import "github.com/docker/docker/engine"
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWithOverrides(t *testing.T) {
+	eng := New()
+	eng.Register("greet", func(job *Job) string {
+		job.Stdout.Write([]byte("parent"))
+		return StatusOK
+	})
+
+	derived := eng.WithOverrides(map[string]func(*Job) string{
+		"greet": func(job *Job) string {
+			job.Stdout.Write([]byte("override"))
+			return StatusOK
+		},
+	})
+
+	out := new(bytes.Buffer)
+	job := derived.Job("greet")
+	job.Stdout = out
+	if err := job.Run(); err != nil {
+		t.Fatal(err)
+	}
+	if out.String() != "override" {
+		t.Fatalf("expected override handler to run, got %q", out.String())
+	}
+
+	other := new(bytes.Buffer)
+	eng.Register("other", func(job *Job) string {
+		job.Stdout.Write([]byte("fell through"))
+		return StatusOK
+	})
+	fallback := derived.Job("other")
+	fallback.Stdout = other
+	if err := fallback.Run(); err != nil {
+		t.Fatal(err)
+	}
+	if other.String() != "fell through" {
+		t.Fatalf("expected parent handler to run for unoverridden name, got %q", other.String())
+	}
+
+	// The parent must be unaffected by the override.
+	parentOut := new(bytes.Buffer)
+	parentJob := eng.Job("greet")
+	parentJob.Stdout = parentOut
+	if err := parentJob.Run(); err != nil {
+		t.Fatal(err)
+	}
+	if parentOut.String() != "parent" {
+		t.Fatalf("expected parent engine to keep its own handler, got %q", parentOut.String())
+	}
+}
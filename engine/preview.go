@@ -0,0 +1,29 @@
+package engine // import "github.com/docker/docker/engine"
+
+import (
+	"encoding/json"
+	"regexp"
+)
+
+// secretKeyPattern matches env keys that conventionally hold sensitive
+// values, for masking in Preview.
+var secretKeyPattern = regexp.MustCompile(`(?i)(secret|token|password|key)`)
+
+// Preview returns a pretty-printed preview of what MarshalJSON would
+// produce, except that values of secret-looking env keys are masked. It is
+// a debugging aid distinct from the wire format: MarshalJSON never
+// redacts, since the remote engine needs the real values to run the job.
+func (job *Job) Preview() string {
+	var masked Env
+	for key, value := range job.env.Map() {
+		if secretKeyPattern.MatchString(key) {
+			value = "***"
+		}
+		masked.Set(key, job.redact(value))
+	}
+	b, err := json.MarshalIndent(wireJob{Name: job.Name, Args: job.Args, Env: masked}, "", "  ")
+	if err != nil {
+		return err.Error()
+	}
+	return string(b)
+}
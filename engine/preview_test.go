@@ -0,0 +1,24 @@
+package engine // import "github.com/docker/docker/engine"
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPreviewMasksSecretsButMarshalJSONDoesNot(t *testing.T) {
+	eng := New()
+	job := eng.Job("test")
+	job.Setenv("API_TOKEN", "supersecret")
+
+	if strings.Contains(job.Preview(), "supersecret") {
+		t.Fatalf("expected Preview to mask the secret, got %s", job.Preview())
+	}
+
+	b, err := job.MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(b), "supersecret") {
+		t.Fatalf("expected MarshalJSON to carry the real value, got %s", b)
+	}
+}
@@ -0,0 +1,23 @@
+package engine // import "github.com/docker/docker/engine"
+
+// RangeEnv iterates the job's environment, last-wins deduplicated by key,
+// invoking fn for each entry. It stops as soon as fn returns false. Unlike
+// Environ, this never builds the full map up front, so a caller that only
+// needs the first few matching keys of a very large environment can avoid
+// the allocation.
+func (job *Job) RangeEnv(fn func(key, value string) bool) {
+	seen := make(map[string]struct{}, len(job.env))
+	for i := len(job.env) - 1; i >= 0; i-- {
+		key, value, ok := splitKV(job.env[i])
+		if !ok {
+			continue
+		}
+		if _, dup := seen[key]; dup {
+			continue
+		}
+		seen[key] = struct{}{}
+		if !fn(key, value) {
+			return
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package engine // import "github.com/docker/docker/engine"
+
+import "testing"
+
+func TestRangeEnvEarlyTermination(t *testing.T) {
+	eng := New()
+	job := eng.Job("test")
+	job.Setenv("A", "1")
+	job.Setenv("B", "2")
+	job.Setenv("C", "3")
+
+	var seen int
+	job.RangeEnv(func(key, value string) bool {
+		seen++
+		return seen < 2
+	})
+	if seen != 2 {
+		t.Fatalf("expected iteration to stop after 2 entries, got %d", seen)
+	}
+}
+
+func TestRangeEnvDedupLastWins(t *testing.T) {
+	eng := New()
+	job := eng.Job("test")
+	job.env = append(job.env, "DUP=first", "DUP=second")
+
+	var got []string
+	job.RangeEnv(func(key, value string) bool {
+		if key == "DUP" {
+			got = append(got, value)
+		}
+		return true
+	})
+	if len(got) != 1 || got[0] != "second" {
+		t.Fatalf("expected a single last-wins DUP entry, got %v", got)
+	}
+}
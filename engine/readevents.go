@@ -0,0 +1,32 @@
+package engine // import "github.com/docker/docker/engine"
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// ReadEvents decodes a stream of newline-delimited JSON Events, as emitted
+// by Job.Emit, delivering them on the returned channel. It is the
+// client-side counterpart to Emit/Progress. Both channels are closed once r
+// is exhausted; a decode error is reported on the error channel and stops
+// further delivery.
+func ReadEvents(r io.Reader) (<-chan Event, <-chan error) {
+	events := make(chan Event)
+	errs := make(chan error, 1)
+	go func() {
+		defer close(events)
+		defer close(errs)
+		dec := json.NewDecoder(r)
+		for {
+			var e Event
+			if err := dec.Decode(&e); err != nil {
+				if err != io.EOF {
+					errs <- err
+				}
+				return
+			}
+			events <- e
+		}
+	}()
+	return events, errs
+}
@@ -0,0 +1,32 @@
+package engine // import "github.com/docker/docker/engine"
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestReadEvents(t *testing.T) {
+	src := `{"name":"start"}
+{"name":"progress","data":{"current":1,"total":2}}
+{"name":"done"}
+`
+	events, errs := ReadEvents(strings.NewReader(src))
+
+	var names []string
+	for e := range events {
+		names = append(names, e.Name)
+	}
+	if err := <-errs; err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"start", "progress", "done"}
+	if len(names) != len(want) {
+		t.Fatalf("expected %v, got %v", want, names)
+	}
+	for i := range want {
+		if names[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, names)
+		}
+	}
+}
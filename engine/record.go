@@ -0,0 +1,56 @@
+package engine // import "github.com/docker/docker/engine"
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+)
+
+// wireRecordedJob is the JSON wire format for a recorded Job, extending
+// wireJob with the contents of Stdin so the run can be faithfully
+// reproduced later.
+type wireRecordedJob struct {
+	Name  string
+	Args  []string
+	Env   Env
+	Stdin string
+}
+
+// Record serializes the job's name, arguments, environment and Stdin
+// contents to w, for replaying later against the same engine to reproduce
+// a reported failure. Recording consumes job.Stdin; it is replaced with a
+// fresh reader over the same bytes so the job can still be run normally
+// afterward.
+func (job *Job) Record(w io.Writer) error {
+	var stdin string
+	if job.Stdin != nil {
+		data, err := io.ReadAll(job.Stdin)
+		if err != nil {
+			return err
+		}
+		stdin = string(data)
+		job.Stdin = strings.NewReader(stdin)
+	}
+	return json.NewEncoder(w).Encode(wireRecordedJob{
+		Name:  job.Name,
+		Args:  job.Args,
+		Env:   job.env,
+		Stdin: stdin,
+	})
+}
+
+// Replay reconstructs a job recorded by Record from r and runs it against
+// eng, capturing its Stdout via CaptureStdout so the caller can inspect
+// the reproduced output (job.Stdout.(*CapturedOutput).String()) alongside
+// the job's status.
+func (eng *Engine) Replay(r io.Reader) (*Job, error) {
+	var rec wireRecordedJob
+	if err := json.NewDecoder(r).Decode(&rec); err != nil {
+		return nil, err
+	}
+	job := eng.Job(rec.Name, rec.Args...)
+	job.env = rec.Env
+	job.Stdin = strings.NewReader(rec.Stdin)
+	job.CaptureStdout()
+	return job, job.Run()
+}
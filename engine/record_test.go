@@ -0,0 +1,42 @@
+package engine // import "github.com/docker/docker/engine"
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestRecordReplay(t *testing.T) {
+	eng := New()
+	eng.Register("echo", func(j *Job) string {
+		data, _ := io.ReadAll(j.Stdin)
+		j.Stdout.Write(data)
+		return StatusOK
+	})
+
+	original := eng.Job("echo")
+	original.Stdin = strings.NewReader("hello from stdin")
+	var recorded bytes.Buffer
+	if err := original.Record(&recorded); err != nil {
+		t.Fatal(err)
+	}
+
+	var originalOut bytes.Buffer
+	original.Stdout = &originalOut
+	if err := original.Run(); err != nil {
+		t.Fatal(err)
+	}
+
+	replayed, err := eng.Replay(&recorded)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if originalOut.String() != "hello from stdin" {
+		t.Fatalf("unexpected original output %q", originalOut.String())
+	}
+	if got := replayed.Stdout.(*CapturedOutput).String(); got != "hello from stdin" {
+		t.Fatalf("expected replay to reproduce the same output, got %q", got)
+	}
+}
@@ -0,0 +1,20 @@
+package engine // import "github.com/docker/docker/engine"
+
+import "regexp"
+
+// AddRedactPattern registers re as an additional mask applied to values in
+// String, CommandLine and Preview output: any substring matching re is
+// replaced with "***". This complements secretKeyPattern's matching by env
+// key name with matching by value shape, such as a token or API key
+// pattern that could turn up under an innocuous-looking key.
+func (job *Job) AddRedactPattern(re *regexp.Regexp) {
+	job.redactPatterns = append(job.redactPatterns, re)
+}
+
+// redact applies every pattern registered via AddRedactPattern to s.
+func (job *Job) redact(s string) string {
+	for _, re := range job.redactPatterns {
+		s = re.ReplaceAllString(s, "***")
+	}
+	return s
+}
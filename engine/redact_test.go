@@ -0,0 +1,23 @@
+package engine // import "github.com/docker/docker/engine"
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestAddRedactPatternMasksMatchingValues(t *testing.T) {
+	eng := New()
+	job := eng.Job("test")
+	job.Setenv("NOTE", "tok_abc123xyz")
+	job.Setenv("NAME", "alice")
+	job.AddRedactPattern(regexp.MustCompile(`tok_[a-zA-Z0-9]+`))
+
+	line := job.CommandLine()
+	if strings.Contains(line, "tok_abc123xyz") {
+		t.Fatalf("expected token-like value to be masked, got %q", line)
+	}
+	if !strings.Contains(line, "alice") {
+		t.Fatalf("expected unrelated value to remain visible, got %q", line)
+	}
+}
@@ -0,0 +1,18 @@
+package engine // import "github.com/docker/docker/engine"
+
+// RegisterErr registers handler under name, adapting its error-returning
+// signature to the Handler convention: a nil error becomes the job's
+// success status, and a non-nil error's message becomes the job's status
+// string while the error itself is preserved so Run returns it wrapped,
+// letting callers use errors.Is/As on it instead of matching status
+// strings.
+func (eng *Engine) RegisterErr(name string, handler func(job *Job) error) error {
+	return eng.Register(name, func(job *Job) string {
+		err := handler(job)
+		if err == nil {
+			return job.successStatus()
+		}
+		job.runErr = err
+		return err.Error()
+	})
+}
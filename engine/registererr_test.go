@@ -0,0 +1,43 @@
+package engine // import "github.com/docker/docker/engine"
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+var errNotFoundInStore = errors.New("record not found in store")
+
+func TestRegisterErrSurfacesWrappedSentinel(t *testing.T) {
+	eng := New()
+	if err := eng.RegisterErr("lookup", func(job *Job) error {
+		return fmt.Errorf("looking up %q: %w", job.Args[0], errNotFoundInStore)
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	err := eng.Job("lookup", "widget").Run()
+	if err == nil {
+		t.Fatal("expected an error from the failing handler")
+	}
+	if !errors.Is(err, errNotFoundInStore) {
+		t.Fatalf("expected errors.Is to see through to the sentinel, got %v", err)
+	}
+}
+
+func TestRegisterErrNilBecomesSuccess(t *testing.T) {
+	eng := New()
+	if err := eng.RegisterErr("ok", func(job *Job) error {
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	job := eng.Job("ok")
+	if err := job.Run(); err != nil {
+		t.Fatalf("expected success, got %v", err)
+	}
+	if !job.Succeeded() {
+		t.Fatal("expected Succeeded to report true")
+	}
+}
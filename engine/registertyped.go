@@ -0,0 +1,71 @@
+package engine // import "github.com/docker/docker/engine"
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// exportEnvInto populates dst, a pointer to struct, from job's environment.
+// Fields are matched by an "env" tag, falling back to the upper-cased field
+// name -- the same convention UnknownEnvKeys uses to spot typos. String,
+// bool, int, and float fields are converted from their string env value;
+// an unconvertible value is reported as an error naming the offending key.
+func exportEnvInto(job *Job, dst interface{}) error {
+	v := reflect.ValueOf(dst).Elem()
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		key := f.Tag.Get("env")
+		if key == "" {
+			key = strings.ToUpper(f.Name)
+		}
+		if !job.HasEnv(key) {
+			continue
+		}
+		raw := job.Getenv(key)
+		field := v.Field(i)
+		switch field.Kind() {
+		case reflect.String:
+			field.SetString(raw)
+		case reflect.Bool:
+			b, err := strconv.ParseBool(raw)
+			if err != nil {
+				return fmt.Errorf("%s: invalid bool: %v", key, err)
+			}
+			field.SetBool(b)
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			n, err := strconv.ParseInt(raw, 10, 64)
+			if err != nil {
+				return fmt.Errorf("%s: invalid int: %v", key, err)
+			}
+			field.SetInt(n)
+		case reflect.Float32, reflect.Float64:
+			f, err := strconv.ParseFloat(raw, 64)
+			if err != nil {
+				return fmt.Errorf("%s: invalid float: %v", key, err)
+			}
+			field.SetFloat(f)
+		default:
+			return fmt.Errorf("%s: unsupported field type %s", key, field.Kind())
+		}
+	}
+	return nil
+}
+
+// RegisterTyped registers a handler that receives its config as a decoded
+// T instead of parsing the job's env by hand. T's fields are matched to
+// env keys the same way UnknownEnvKeys does (an "env" tag, or the
+// upper-cased field name). A decode failure fails the job with StatusErr
+// before handler runs.
+func RegisterTyped[T any](eng *Engine, name string, handler func(*Job, T) string) error {
+	return eng.Register(name, func(job *Job) string {
+		var config T
+		if err := exportEnvInto(job, &config); err != nil {
+			job.status = StatusErr
+			return job.status
+		}
+		return handler(job, config)
+	})
+}
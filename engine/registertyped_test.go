@@ -0,0 +1,30 @@
+package engine // import "github.com/docker/docker/engine"
+
+import "testing"
+
+type greetConfig struct {
+	Name string
+	Port int
+}
+
+func TestRegisterTyped(t *testing.T) {
+	eng := New()
+	var got greetConfig
+	if err := RegisterTyped(eng, "greet", func(job *Job, cfg greetConfig) string {
+		got = cfg
+		return StatusOK
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	job := eng.Job("greet")
+	job.Setenv("NAME", "alice")
+	job.Setenv("PORT", "8080")
+	if err := job.Run(); err != nil {
+		t.Fatal(err)
+	}
+
+	if got.Name != "alice" || got.Port != 8080 {
+		t.Fatalf("expected decoded config {alice 8080}, got %+v", got)
+	}
+}
@@ -0,0 +1,126 @@
+package engine // import "github.com/docker/docker/engine"
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// patternEntry matches names by prefix, the simplest useful notion of
+// "pattern" dispatch: a handler registered for prefix "foo." matches any
+// name starting with "foo.".
+type patternEntry struct {
+	prefix  string
+	handler Handler
+}
+
+// Registry holds a set of named Handlers, independent of any particular
+// Engine. Multiple Engines can share a Registry (via NewEngineWithRegistry)
+// to resolve the same handlers, or each use their own for isolation.
+//
+// Names resolve in four ways, in order: an exact registration, an alias to
+// an exact registration, a prefix pattern, and finally a catchall that
+// matches anything else. Resolve reports which of these applied.
+type Registry struct {
+	mu       sync.Mutex
+	handlers map[string]Handler
+	aliases  map[string]string
+	patterns []patternEntry
+	catchall Handler
+}
+
+// NewRegistry returns a new, empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{handlers: make(map[string]Handler)}
+}
+
+// Register associates name with handler. It returns an error if a handler
+// is already registered for name.
+func (r *Registry) Register(name string, handler Handler) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.handlers[name]; exists {
+		return fmt.Errorf("can't overwrite handler for command %s", name)
+	}
+	r.handlers[name] = handler
+	return nil
+}
+
+// RegisterAlias makes alias resolve to whatever handler is registered (now
+// or later) for target.
+func (r *Registry) RegisterAlias(alias, target string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.aliases == nil {
+		r.aliases = make(map[string]string)
+	}
+	r.aliases[alias] = target
+}
+
+// RegisterPattern makes handler match any name starting with prefix, for
+// names with no exact or alias registration.
+func (r *Registry) RegisterPattern(prefix string, handler Handler) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.patterns = append(r.patterns, patternEntry{prefix: prefix, handler: handler})
+}
+
+// SetCatchall registers handler to match any name with no exact, alias, or
+// pattern registration.
+func (r *Registry) SetCatchall(handler Handler) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.catchall = handler
+}
+
+// Lookup resolves name to a Handler, trying an exact registration, then an
+// alias, then a prefix pattern, then the catchall, in that order.
+func (r *Registry) Lookup(name string) (Handler, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.lookupLocked(name)
+}
+
+func (r *Registry) lookupLocked(name string) (Handler, bool) {
+	if handler, exists := r.handlers[name]; exists {
+		return handler, true
+	}
+	if target, ok := r.aliases[name]; ok {
+		if handler, exists := r.handlers[target]; exists {
+			return handler, true
+		}
+	}
+	for _, p := range r.patterns {
+		if strings.HasPrefix(name, p.prefix) {
+			return p.handler, true
+		}
+	}
+	if r.catchall != nil {
+		return r.catchall, true
+	}
+	return nil, false
+}
+
+// Resolve reports how name would resolve: "exact", "alias", "pattern",
+// "catchall", or found=false if nothing matches.
+func (r *Registry) Resolve(name string) (kind string, found bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.handlers[name]; exists {
+		return "exact", true
+	}
+	if target, ok := r.aliases[name]; ok {
+		if _, exists := r.handlers[target]; exists {
+			return "alias", true
+		}
+	}
+	for _, p := range r.patterns {
+		if strings.HasPrefix(name, p.prefix) {
+			return "pattern", true
+		}
+	}
+	if r.catchall != nil {
+		return "catchall", true
+	}
+	return "", false
+}
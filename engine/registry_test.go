@@ -0,0 +1,39 @@
+package engine // import "github.com/docker/docker/engine"
+
+import "testing"
+
+func TestSharedRegistry(t *testing.T) {
+	registry := NewRegistry()
+	if err := registry.Register("ping", func(job *Job) string {
+		return StatusOK
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	a := NewEngineWithRegistry(registry)
+	b := NewEngineWithRegistry(registry)
+
+	if err := a.Job("ping").Run(); err != nil {
+		t.Fatalf("engine a: %v", err)
+	}
+	if err := b.Job("ping").Run(); err != nil {
+		t.Fatalf("engine b: %v", err)
+	}
+}
+
+func TestIsolatedRegistries(t *testing.T) {
+	a := New()
+	b := New()
+	if err := a.Register("ping", func(job *Job) string {
+		return StatusOK
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := a.Job("ping").Run(); err != nil {
+		t.Fatalf("engine a: %v", err)
+	}
+	if err := b.Job("ping").Run(); err == nil {
+		t.Fatal("expected engine b to not resolve a's handler")
+	}
+}
@@ -0,0 +1,19 @@
+package engine // import "github.com/docker/docker/engine"
+
+// RegisterAlias makes alias resolve to whatever handler is registered for
+// target in this engine's Registry.
+func (eng *Engine) RegisterAlias(alias, target string) {
+	eng.registry.RegisterAlias(alias, target)
+}
+
+// RegisterPattern registers handler to match any job name starting with
+// prefix that has no exact or alias registration.
+func (eng *Engine) RegisterPattern(prefix string, handler Handler) {
+	eng.registry.RegisterPattern(prefix, handler)
+}
+
+// SetCatchall registers handler to match any job name with no exact,
+// alias, or pattern registration.
+func (eng *Engine) SetCatchall(handler Handler) {
+	eng.registry.SetCatchall(handler)
+}
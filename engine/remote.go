@@ -0,0 +1,73 @@
+package engine // import "github.com/docker/docker/engine"
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// RemoteEngine is a client for an Engine exposed over HTTP via
+// Engine.ServeHTTP. It lets the same Job API be used against a local or a
+// remote engine.
+type RemoteEngine struct {
+	baseURL string
+	client  *http.Client
+}
+
+// DialEngine returns a RemoteEngine that sends jobs to the engine served at
+// baseURL.
+func DialEngine(baseURL string) *RemoteEngine {
+	return &RemoteEngine{
+		baseURL: baseURL,
+		client:  http.DefaultClient,
+	}
+}
+
+// RemoteJob is a Job whose Run posts its definition to a RemoteEngine and
+// streams back the result, instead of invoking a local handler.
+type RemoteJob struct {
+	*Job
+	remote *RemoteEngine
+}
+
+// Job creates a RemoteJob with the given name and arguments, to be executed
+// on eng's remote engine when Run is called.
+func (eng *RemoteEngine) Job(name string, args ...string) *RemoteJob {
+	return &RemoteJob{
+		Job: &Job{
+			Name:   name,
+			Args:   args,
+			Stdout: io.Discard,
+			Stderr: io.Discard,
+		},
+		remote: eng,
+	}
+}
+
+// Run posts the job's definition to the remote engine and copies the
+// response body to Stdout as it streams in. It returns an error if the
+// remote status is not StatusOK.
+func (rj *RemoteJob) Run() error {
+	body, err := json.Marshal(rj.Job)
+	if err != nil {
+		return err
+	}
+	resp, err := rj.remote.client.Post(rj.remote.baseURL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if _, err := io.Copy(rj.Job.Stdout, resp.Body); err != nil {
+		return err
+	}
+
+	status := resp.Trailer.Get("X-Job-Status")
+	rj.Job.status = status
+	if status != StatusOK {
+		return fmt.Errorf("%s: %s", rj.Job.Name, status)
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+package engine // import "github.com/docker/docker/engine"
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRemoteEngineRun(t *testing.T) {
+	eng := New()
+	if err := eng.Register("greet", func(job *Job) string {
+		job.Stdout.Write([]byte("hello " + job.Getenv("NAME")))
+		return StatusOK
+	}); err != nil {
+		t.Fatal(err)
+	}
+	srv := httptest.NewServer(eng)
+	defer srv.Close()
+
+	remote := DialEngine(srv.URL)
+	job := remote.Job("greet")
+	job.Setenv("NAME", "world")
+	var out bytes.Buffer
+	job.Stdout = &out
+
+	if err := job.Run(); err != nil {
+		t.Fatal(err)
+	}
+	if out.String() != "hello world" {
+		t.Fatalf("expected %q, got %q", "hello world", out.String())
+	}
+}
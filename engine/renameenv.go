@@ -0,0 +1,15 @@
+package engine // import "github.com/docker/docker/engine"
+
+// RenameEnv migrates a value from oldKey to newKey: if oldKey is set and
+// newKey is not, its value is copied to newKey and oldKey is removed. It is
+// a no-op if oldKey is unset or newKey is already set, so repeated calls
+// (or calls against env that's already been migrated) are harmless. This
+// supports renaming config keys without breaking callers still using the
+// old name.
+func (job *Job) RenameEnv(oldKey, newKey string) {
+	if !job.HasEnv(oldKey) || job.HasEnv(newKey) {
+		return
+	}
+	job.Setenv(newKey, job.Getenv(oldKey))
+	job.env.Unset(job.namespaced(oldKey))
+}
@@ -0,0 +1,18 @@
+package engine // import "github.com/docker/docker/engine"
+
+import "testing"
+
+func TestRenameEnv(t *testing.T) {
+	eng := New()
+	job := eng.Job("test")
+	job.Setenv("OLD_NAME", "value")
+
+	job.RenameEnv("OLD_NAME", "NEW_NAME")
+
+	if job.HasEnv("OLD_NAME") {
+		t.Fatal("expected old key to be removed")
+	}
+	if got := job.Getenv("NEW_NAME"); got != "value" {
+		t.Fatalf("expected NEW_NAME=value, got %q", got)
+	}
+}
@@ -0,0 +1,23 @@
+package engine // import "github.com/docker/docker/engine"
+
+// StatusMissingRequiredEnv is the status returned by a RequireEnv-wrapped
+// handler when one of its required keys is absent.
+const StatusMissingRequiredEnv = "missing required env"
+
+// RequireEnv returns middleware that rejects a job with
+// StatusMissingRequiredEnv before invoking the wrapped handler if any of
+// keys is absent from the job's environment. This centralizes a guard that
+// would otherwise be repeated as a ValidateEnv call at the top of every
+// handler in a group.
+func RequireEnv(keys ...string) func(Handler) Handler {
+	return func(next Handler) Handler {
+		return func(job *Job) string {
+			for _, key := range keys {
+				if !job.HasEnv(key) {
+					return StatusMissingRequiredEnv
+				}
+			}
+			return next(job)
+		}
+	}
+}
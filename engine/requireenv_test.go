@@ -0,0 +1,24 @@
+package engine // import "github.com/docker/docker/engine"
+
+import "testing"
+
+func TestRequireEnvRejectsMissingKey(t *testing.T) {
+	eng := New()
+	eng.Register("test", RequireEnv("TOKEN")(func(j *Job) string {
+		return StatusOK
+	}))
+
+	job := eng.Job("test")
+	if err := job.Run(); err == nil {
+		t.Fatal("expected an error for missing required env")
+	}
+	if job.Status() != StatusMissingRequiredEnv {
+		t.Fatalf("expected status %q, got %q", StatusMissingRequiredEnv, job.Status())
+	}
+
+	job = eng.Job("test")
+	job.Setenv("TOKEN", "abc")
+	if err := job.Run(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,37 @@
+package engine // import "github.com/docker/docker/engine"
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestResetStreams(t *testing.T) {
+	eng := New()
+	if err := eng.Register("write", func(job *Job) string {
+		job.Stdout.Write([]byte("output"))
+		return StatusOK
+	}); err != nil {
+		t.Fatal(err)
+	}
+	job := eng.Job("write")
+
+	var first bytes.Buffer
+	job.Stdout = &first
+	if err := job.Run(); err != nil {
+		t.Fatal(err)
+	}
+
+	job.ResetStreams()
+	var second bytes.Buffer
+	job.Stdout = &second
+	if err := job.Run(); err != nil {
+		t.Fatal(err)
+	}
+
+	if first.String() != "output" {
+		t.Fatalf("expected first run output to be preserved, got %q", first.String())
+	}
+	if second.String() != "output" {
+		t.Fatalf("expected second run to write fresh output, got %q", second.String())
+	}
+}
@@ -0,0 +1,9 @@
+package engine // import "github.com/docker/docker/engine"
+
+// Resolve reports how name would resolve to a handler: "exact", "alias",
+// "pattern", or "catchall", or found=false if nothing in the Registry
+// matches. This demystifies dispatch when exact registrations, aliases,
+// patterns, and a catchall coexist.
+func (eng *Engine) Resolve(name string) (kind string, found bool) {
+	return eng.registry.Resolve(name)
+}
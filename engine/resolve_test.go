@@ -0,0 +1,34 @@
+package engine // import "github.com/docker/docker/engine"
+
+import "testing"
+
+func TestResolveKinds(t *testing.T) {
+	eng := New()
+	eng.Register("build", func(*Job) string { return StatusOK })
+	eng.RegisterAlias("compile", "build")
+	eng.RegisterPattern("plugin.", func(*Job) string { return StatusOK })
+	eng.SetCatchall(func(*Job) string { return StatusOK })
+
+	cases := []struct {
+		name string
+		want string
+	}{
+		{"build", "exact"},
+		{"compile", "alias"},
+		{"plugin.foo", "pattern"},
+		{"anything-else", "catchall"},
+	}
+	for _, c := range cases {
+		kind, found := eng.Resolve(c.name)
+		if !found || kind != c.want {
+			t.Fatalf("Resolve(%q) = (%q, %v), want (%q, true)", c.name, kind, found, c.want)
+		}
+	}
+}
+
+func TestResolveNotFoundWithoutCatchall(t *testing.T) {
+	eng := New()
+	if _, found := eng.Resolve("nope"); found {
+		t.Fatal("expected no resolution without any registration or catchall")
+	}
+}
@@ -0,0 +1,42 @@
+package engine // import "github.com/docker/docker/engine"
+
+import (
+	"fmt"
+	"runtime/debug"
+)
+
+// StatusPanic is the status recorded when a Job's handler panics.
+const StatusPanic = "panic"
+
+// Result carries the outcome of a job run as a value, for callers that want
+// more than the error returned by Run.
+type Result struct {
+	// Status is the status string recorded by the run, or StatusPanic if
+	// the handler panicked.
+	Status string
+	// Err is the error the run failed with, if any.
+	Err error
+	// Stack holds the captured stack trace if the handler panicked, and is
+	// nil otherwise.
+	Stack []byte
+}
+
+// RunResult executes the job as Run does, but returns a Result instead of
+// only an error, and recovers a panicking handler rather than propagating
+// it to the caller. On panic, the returned Result has Status set to
+// StatusPanic, Err wrapping the recovered value, and Stack populated with
+// the captured stack trace.
+func (job *Job) RunResult() (result *Result) {
+	result = &Result{}
+	defer func() {
+		if r := recover(); r != nil {
+			job.status = StatusPanic
+			result.Status = StatusPanic
+			result.Err = fmt.Errorf("%s: %w: %v", job.Name, ErrPanic, r)
+			result.Stack = debug.Stack()
+		}
+	}()
+	result.Err = job.Run()
+	result.Status = job.status
+	return result
+}
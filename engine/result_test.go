@@ -0,0 +1,24 @@
+package engine // import "github.com/docker/docker/engine"
+
+import "testing"
+
+func TestRunResultPanic(t *testing.T) {
+	eng := New()
+	if err := eng.Register("panics", func(job *Job) string {
+		panic("boom")
+	}); err != nil {
+		t.Fatal(err)
+	}
+	job := eng.Job("panics")
+
+	result := job.RunResult()
+	if result.Status != StatusPanic {
+		t.Fatalf("expected status %q, got %q", StatusPanic, result.Status)
+	}
+	if result.Err == nil {
+		t.Fatal("expected a non-nil Err")
+	}
+	if len(result.Stack) == 0 {
+		t.Fatal("expected a captured stack trace")
+	}
+}
@@ -0,0 +1,28 @@
+package engine // import "github.com/docker/docker/engine"
+
+import "encoding/json"
+
+// wireResult is the JSON wire format for a Job's outcome, returned by a
+// remote engine to a client after running a job.
+type wireResult struct {
+	Status     string            `json:"status"`
+	DurationMs int64             `json:"duration_ms"`
+	Results    map[string]string `json:"results,omitempty"`
+	Error      string            `json:"error,omitempty"`
+}
+
+// ResultJSON serializes the job's outcome -- status, run duration, any
+// results recorded via SetResult, and the failure reason if it didn't
+// succeed -- for a remote engine to return to a client. It should be
+// called after the job has run.
+func (job *Job) ResultJSON() ([]byte, error) {
+	result := wireResult{
+		Status:     job.Status(),
+		DurationMs: job.duration.Milliseconds(),
+		Results:    job.results,
+	}
+	if !job.Succeeded() {
+		result.Error = job.Status()
+	}
+	return json.Marshal(result)
+}
@@ -0,0 +1,35 @@
+package engine // import "github.com/docker/docker/engine"
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestResultJSON(t *testing.T) {
+	eng := New()
+	eng.Register("test", func(j *Job) string {
+		j.SetResult("count", "3")
+		return StatusOK
+	})
+	job := eng.Job("test")
+	if err := job.Run(); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := job.ResultJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out map[string]interface{}
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatal(err)
+	}
+	if out["status"] != StatusOK {
+		t.Fatalf("expected status %q, got %v", StatusOK, out["status"])
+	}
+	results, ok := out["results"].(map[string]interface{})
+	if !ok || results["count"] != "3" {
+		t.Fatalf("expected results.count = \"3\", got %v", out["results"])
+	}
+}
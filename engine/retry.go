@@ -0,0 +1,24 @@
+package engine // import "github.com/docker/docker/engine"
+
+// RunWithRetry calls Run up to maxAttempts times, stopping as soon as an
+// attempt succeeds. It records the status of every attempt, retrievable via
+// AttemptStatuses, so callers can tell whether failures were consistent or
+// varied across retries. The error from the final attempt is returned.
+func (job *Job) RunWithRetry(maxAttempts int) error {
+	var err error
+	job.attemptStatuses = nil
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		err = job.Run()
+		job.attemptStatuses = append(job.attemptStatuses, job.status)
+		if job.Succeeded() {
+			return nil
+		}
+	}
+	return err
+}
+
+// AttemptStatuses returns the status recorded after each attempt made by
+// the most recent call to RunWithRetry, in order.
+func (job *Job) AttemptStatuses() []string {
+	return job.attemptStatuses
+}
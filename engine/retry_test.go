@@ -0,0 +1,31 @@
+package engine // import "github.com/docker/docker/engine"
+
+import "testing"
+
+func TestRunWithRetryRecordsAttemptStatuses(t *testing.T) {
+	eng := New()
+	attempts := 0
+	eng.Register("flaky", func(job *Job) string {
+		attempts++
+		if attempts < 3 {
+			return "fail"
+		}
+		return StatusOK
+	})
+	job := eng.Job("flaky")
+
+	if err := job.RunWithRetry(5); err != nil {
+		t.Fatalf("expected eventual success, got %v", err)
+	}
+
+	got := job.AttemptStatuses()
+	want := []string{"fail", "fail", StatusOK}
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, got)
+		}
+	}
+}
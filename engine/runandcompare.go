@@ -0,0 +1,28 @@
+package engine // import "github.com/docker/docker/engine"
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// RunAndCompare runs the job with its Stdout captured and returns an error
+// describing the difference if the captured output doesn't match expected.
+// Trailing newlines on both sides are normalized away before comparing, so
+// a handler's trailing newline doesn't cause spurious mismatches. This
+// streamlines writing golden-output tests for handlers.
+func (job *Job) RunAndCompare(expected string) error {
+	out := new(bytes.Buffer)
+	job.Stdout = out
+
+	if err := job.Run(); err != nil {
+		return err
+	}
+
+	got := strings.TrimRight(out.String(), "\n")
+	want := strings.TrimRight(expected, "\n")
+	if got != want {
+		return fmt.Errorf("%s: output mismatch:\n--- want\n%s\n--- got\n%s", job.Name, want, got)
+	}
+	return nil
+}
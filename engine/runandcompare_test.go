@@ -0,0 +1,34 @@
+package engine // import "github.com/docker/docker/engine"
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRunAndCompareMatch(t *testing.T) {
+	eng := New()
+	eng.Register("greet", func(job *Job) string {
+		job.Stdout.Write([]byte("hello\n"))
+		return StatusOK
+	})
+	job := eng.Job("greet")
+	if err := job.RunAndCompare("hello"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRunAndCompareMismatch(t *testing.T) {
+	eng := New()
+	eng.Register("greet", func(job *Job) string {
+		job.Stdout.Write([]byte("goodbye\n"))
+		return StatusOK
+	})
+	job := eng.Job("greet")
+	err := job.RunAndCompare("hello")
+	if err == nil {
+		t.Fatal("expected a mismatch error")
+	}
+	if !strings.Contains(err.Error(), "hello") || !strings.Contains(err.Error(), "goodbye") {
+		t.Fatalf("expected a readable diff, got %v", err)
+	}
+}
@@ -0,0 +1,47 @@
+package engine // import "github.com/docker/docker/engine"
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+)
+
+// RunContext runs the job's handler like Run, but returns early with a
+// StatusCancelled status if ctx is cancelled before the handler finishes.
+// OnExit hooks still run on the cancellation path, so cleanup registered
+// via OnExit is not skipped.
+//
+// The handler itself is not interrupted; RunContext only stops waiting for
+// it and reports cancellation to the caller. A handler sees the
+// cancellation only through Emit and StartHeartbeat, which honor ctx.
+func (job *Job) RunContext(ctx context.Context) error {
+	if job.Eng != nil && job.Eng.baseCtx != nil {
+		ctx = mergeContext(ctx, job.Eng.baseCtx)
+	}
+	job.ctx = ctx
+	atomic.StoreInt32(&job.state, int32(Running))
+	defer atomic.StoreInt32(&job.state, int32(Finished))
+	defer job.runExitHooks()
+
+	if job.handler == nil {
+		job.status = StatusNotFound
+		return fmt.Errorf("%s: %w", job.Name, ErrHandlerNotFound)
+	}
+
+	done := make(chan string, 1)
+	go func() {
+		done <- job.handler(job)
+	}()
+
+	select {
+	case status := <-done:
+		job.status = status
+		if !job.Succeeded() {
+			return fmt.Errorf("%s: %s", job.Name, status)
+		}
+		return nil
+	case <-ctx.Done():
+		job.status = StatusCancelled
+		return fmt.Errorf("%s: %w", job.Name, ErrCancelled)
+	}
+}
@@ -0,0 +1,38 @@
+package engine // import "github.com/docker/docker/engine"
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestRunContextCancellationRunsOnExit(t *testing.T) {
+	eng := New()
+	if err := eng.Register("slow", func(job *Job) string {
+		time.Sleep(time.Hour)
+		return StatusOK
+	}); err != nil {
+		t.Fatal(err)
+	}
+	job := eng.Job("slow")
+
+	hookRan := make(chan struct{})
+	job.OnExit(func() { close(hookRan) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := job.RunContext(ctx)
+	if err == nil {
+		t.Fatal("expected a cancellation error")
+	}
+	if job.Status() != StatusCancelled {
+		t.Fatalf("expected status %q, got %q", StatusCancelled, job.Status())
+	}
+
+	select {
+	case <-hookRan:
+	case <-time.After(time.Second):
+		t.Fatal("expected OnExit hook to run on cancellation")
+	}
+}
@@ -0,0 +1,11 @@
+package engine // import "github.com/docker/docker/engine"
+
+// RunEvents installs an event recorder as the job's Stdout, runs the job,
+// and returns every event it emitted along with the run error. This is the
+// one-call path for callers that want a handler's progress events without
+// separately wiring up RecordEvents and Run.
+func (job *Job) RunEvents() ([]Event, error) {
+	recorder := job.RecordEvents()
+	err := job.Run()
+	return recorder.Events(), err
+}
@@ -0,0 +1,21 @@
+package engine // import "github.com/docker/docker/engine"
+
+import "testing"
+
+func TestRunEvents(t *testing.T) {
+	eng := New()
+	eng.Register("progresses", func(job *Job) string {
+		job.Progress(1, 2)
+		job.Progress(2, 2)
+		return StatusOK
+	})
+	job := eng.Job("progresses")
+
+	events, err := job.RunEvents()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 2 || events[0].Name != "progress" || events[1].Name != "progress" {
+		t.Fatalf("expected two progress events in order, got %v", events)
+	}
+}
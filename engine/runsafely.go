@@ -0,0 +1,56 @@
+package engine // import "github.com/docker/docker/engine"
+
+import (
+	"fmt"
+	"io"
+	"time"
+)
+
+// limitedWriter forwards writes to w until limit bytes have been written in
+// total, after which further writes are silently dropped rather than
+// erroring, so a flooding handler doesn't also fail on write errors.
+type limitedWriter struct {
+	w        io.Writer
+	n, limit int64
+}
+
+func (lw *limitedWriter) Write(p []byte) (int, error) {
+	if lw.n >= lw.limit {
+		return len(p), nil
+	}
+	remaining := lw.limit - lw.n
+	if int64(len(p)) > remaining {
+		p = p[:remaining]
+	}
+	written, err := lw.w.Write(p)
+	lw.n += int64(written)
+	return len(p), err
+}
+
+// RunSafely runs job as the hardened path for handlers that cannot be fully
+// trusted: it recovers a panicking handler (as RunResult does), enforces
+// timeout as a deadline after which it returns without waiting for the
+// handler to exit, and caps the bytes written to each of Stdout and Stderr
+// at maxOutput (each stream has its own limit). It is the composition point for running third-party or
+// plugin-supplied handlers.
+func (eng *Engine) RunSafely(job *Job, timeout time.Duration, maxOutput int64) error {
+	if job.Stdout != nil {
+		job.Stdout = &limitedWriter{w: job.Stdout, limit: maxOutput}
+	}
+	if job.Stderr != nil {
+		job.Stderr = &limitedWriter{w: job.Stderr, limit: maxOutput}
+	}
+
+	done := make(chan *Result, 1)
+	go func() {
+		done <- job.RunResult()
+	}()
+
+	select {
+	case result := <-done:
+		return result.Err
+	case <-time.After(timeout):
+		job.status = StatusTimeout
+		return fmt.Errorf("%s: %w after %s", job.Name, ErrTimeout, timeout)
+	}
+}
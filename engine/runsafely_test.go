@@ -0,0 +1,76 @@
+package engine // import "github.com/docker/docker/engine"
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRunSafelyRecoversPanic(t *testing.T) {
+	eng := New()
+	job := eng.Job("panicky")
+	job.handler = func(*Job) string {
+		panic("boom")
+	}
+	err := eng.RunSafely(job, time.Second, 1024)
+	if err == nil {
+		t.Fatal("expected an error from a panicking handler")
+	}
+	if !errors.Is(err, ErrPanic) {
+		t.Fatalf("expected the panic to be contained and reported as ErrPanic, got %v", err)
+	}
+	if job.Status() != StatusPanic {
+		t.Fatalf("expected status %q, got %q", StatusPanic, job.Status())
+	}
+}
+
+func TestRunSafelyTimesOut(t *testing.T) {
+	eng := New()
+	job := eng.Job("hangs")
+	job.handler = func(*Job) string {
+		time.Sleep(time.Hour)
+		return StatusOK
+	}
+	err := eng.RunSafely(job, 10*time.Millisecond, 1024)
+	if err == nil || !strings.Contains(err.Error(), "timed out") {
+		t.Fatalf("expected a timeout error, got %v", err)
+	}
+}
+
+func TestRunSafelyLimitsOutput(t *testing.T) {
+	eng := New()
+	job := eng.Job("floods")
+	out := new(bytes.Buffer)
+	job.Stdout = out
+	job.handler = func(j *Job) string {
+		j.Stdout.Write([]byte(strings.Repeat("x", 1000)))
+		return StatusOK
+	}
+	if err := eng.RunSafely(job, time.Second, 10); err != nil {
+		t.Fatal(err)
+	}
+	if out.Len() != 10 {
+		t.Fatalf("expected output capped at 10 bytes, got %d", out.Len())
+	}
+}
+
+func TestRunSafelyTimeoutPreservesPartialOutput(t *testing.T) {
+	eng := New()
+	job := eng.Job("writes-then-hangs")
+	out := job.CaptureStdout()
+	job.handler = func(j *Job) string {
+		j.Stdout.Write([]byte("partial"))
+		time.Sleep(time.Hour)
+		return StatusOK
+	}
+
+	err := eng.RunSafely(job, 10*time.Millisecond, 1024)
+	if err == nil || !strings.Contains(err.Error(), "timed out") {
+		t.Fatalf("expected a timeout error, got %v", err)
+	}
+	if got := out.String(); got != "partial" {
+		t.Fatalf("expected partial output to survive the timeout, got %q", got)
+	}
+}
@@ -0,0 +1,30 @@
+package engine // import "github.com/docker/docker/engine"
+
+import "fmt"
+
+// RunTwiceAndCompare runs the job twice, resetting its streams between
+// runs and capturing stdout each time, and returns an error if the two
+// runs produced different statuses or output. It's meant for testing that
+// a handler believed to be idempotent really is, catching nondeterminism
+// such as an embedded timestamp that a single run can't reveal.
+func (job *Job) RunTwiceAndCompare() error {
+	job.ResetStreams()
+	firstOut := job.CaptureStdout()
+	job.Run()
+	firstStatus := job.Status()
+	firstText := firstOut.String()
+
+	job.ResetStreams()
+	secondOut := job.CaptureStdout()
+	job.Run()
+	secondStatus := job.Status()
+	secondText := secondOut.String()
+
+	if firstStatus != secondStatus {
+		return fmt.Errorf("%s: nondeterministic status: %q then %q", job.Name, firstStatus, secondStatus)
+	}
+	if firstText != secondText {
+		return fmt.Errorf("%s: nondeterministic output: %q then %q", job.Name, firstText, secondText)
+	}
+	return nil
+}
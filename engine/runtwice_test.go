@@ -0,0 +1,32 @@
+package engine // import "github.com/docker/docker/engine"
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestRunTwiceAndCompareDeterministicPasses(t *testing.T) {
+	eng := New()
+	eng.Register("stable", func(job *Job) string {
+		fmt.Fprint(job.Stdout, "same every time")
+		return StatusOK
+	})
+
+	if err := eng.Job("stable").RunTwiceAndCompare(); err != nil {
+		t.Fatalf("expected a deterministic handler to pass, got %v", err)
+	}
+}
+
+func TestRunTwiceAndCompareNondeterministicFails(t *testing.T) {
+	eng := New()
+	var calls int
+	eng.Register("flaky", func(job *Job) string {
+		calls++
+		fmt.Fprintf(job.Stdout, "call-%d", calls)
+		return StatusOK
+	})
+
+	if err := eng.Job("flaky").RunTwiceAndCompare(); err == nil {
+		t.Fatal("expected a nondeterministic handler to fail the comparison")
+	}
+}
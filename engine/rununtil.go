@@ -0,0 +1,38 @@
+package engine // import "github.com/docker/docker/engine"
+
+import (
+	"fmt"
+	"sync/atomic"
+)
+
+// RunUntil runs the job's handler like Run, but returns early with a
+// StatusCancelled status if done closes before the handler finishes. It is
+// a context-free counterpart to RunContext, for callers whose codebase
+// predates contexts or simply prefers a plain channel.
+func (job *Job) RunUntil(done <-chan struct{}) error {
+	atomic.StoreInt32(&job.state, int32(Running))
+	defer atomic.StoreInt32(&job.state, int32(Finished))
+	defer job.runExitHooks()
+
+	if job.handler == nil {
+		job.status = StatusNotFound
+		return fmt.Errorf("%s: %w", job.Name, ErrHandlerNotFound)
+	}
+
+	result := make(chan string, 1)
+	go func() {
+		result <- job.handler(job)
+	}()
+
+	select {
+	case status := <-result:
+		job.status = status
+		if !job.Succeeded() {
+			return fmt.Errorf("%s: %s", job.Name, status)
+		}
+		return nil
+	case <-done:
+		job.status = StatusCancelled
+		return fmt.Errorf("%s: %w", job.Name, ErrCancelled)
+	}
+}
@@ -0,0 +1,22 @@
+package engine // import "github.com/docker/docker/engine"
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRunUntilCancelled(t *testing.T) {
+	eng := New()
+	eng.Register("hangs", func(*Job) string {
+		time.Sleep(time.Hour)
+		return StatusOK
+	})
+	job := eng.Job("hangs")
+
+	done := make(chan struct{})
+	close(done)
+
+	if err := job.RunUntil(done); job.Status() != StatusCancelled {
+		t.Fatalf("expected cancelled status, got %q (err %v)", job.Status(), err)
+	}
+}
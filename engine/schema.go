@@ -0,0 +1,65 @@
+package engine // import "github.com/docker/docker/engine"
+
+import "encoding/json"
+
+// EnvDecl describes one environment variable a job expects, as registered
+// via DeclareEnv.
+type EnvDecl struct {
+	Key         string
+	Kind        string // "string", "int", or "bool"
+	Required    bool
+	Description string
+}
+
+// DeclareEnv records that the job expects an environment variable key of
+// the given kind, for self-documenting APIs. EnvSchema renders these
+// declarations as a JSON Schema that clients can use to build a form.
+func (job *Job) DeclareEnv(key, kind string, required bool, description string) {
+	job.envDecls = append(job.envDecls, EnvDecl{
+		Key:         key,
+		Kind:        kind,
+		Required:    required,
+		Description: description,
+	})
+}
+
+// EnvSchema renders the job's DeclareEnv declarations as a JSON Schema
+// object, listing each variable's type, description, and whether it's
+// required.
+func (job *Job) EnvSchema() []byte {
+	properties := make(map[string]interface{}, len(job.envDecls))
+	var required []string
+	for _, d := range job.envDecls {
+		properties[d.Key] = map[string]interface{}{
+			"type":        jsonSchemaType(d.Kind),
+			"description": d.Description,
+		}
+		if d.Required {
+			required = append(required, d.Key)
+		}
+	}
+	schema := map[string]interface{}{
+		"type":       "object",
+		"properties": properties,
+	}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	b, err := json.Marshal(schema)
+	if err != nil {
+		panic(err) // Cannot happen: schema is built from known-good types
+	}
+	return b
+}
+
+// jsonSchemaType maps an EnvDecl.Kind to its JSON Schema type name.
+func jsonSchemaType(kind string) string {
+	switch kind {
+	case "int":
+		return "integer"
+	case "bool":
+		return "boolean"
+	default:
+		return "string"
+	}
+}
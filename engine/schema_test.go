@@ -0,0 +1,28 @@
+package engine // import "github.com/docker/docker/engine"
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestEnvSchema(t *testing.T) {
+	eng := New()
+	job := eng.Job("test")
+	job.DeclareEnv("NAME", "string", true, "service name")
+	job.DeclareEnv("PORT", "int", false, "listen port")
+
+	var schema struct {
+		Type       string                       `json:"type"`
+		Properties map[string]map[string]string `json:"properties"`
+		Required   []string                     `json:"required"`
+	}
+	if err := json.Unmarshal(job.EnvSchema(), &schema); err != nil {
+		t.Fatal(err)
+	}
+	if schema.Properties["NAME"]["type"] != "string" || schema.Properties["PORT"]["type"] != "integer" {
+		t.Fatalf("unexpected property types: %#v", schema.Properties)
+	}
+	if len(schema.Required) != 1 || schema.Required[0] != "NAME" {
+		t.Fatalf("expected only NAME required, got %#v", schema.Required)
+	}
+}
@@ -0,0 +1,60 @@
+package engine // import "github.com/docker/docker/engine"
+
+import (
+	"fmt"
+	"strings"
+)
+
+// secretRefPrefix marks an env value as a reference to resolve via the
+// job's SecretProvider rather than a literal value, mirroring the "@" and
+// "file://" conventions resolveEnvRef uses for file references.
+const secretRefPrefix = "secret://"
+
+// SecretProvider resolves a secret reference, such as a Vault or AWS SSM
+// path, to its value.
+type SecretProvider interface {
+	Resolve(ref string) (string, error)
+}
+
+// SetSecretProvider installs p to resolve env values of the form
+// "secret://path" on Getenv. Resolution happens lazily, the first time
+// such a value is read, and the result is cached on the job for
+// subsequent reads.
+func (job *Job) SetSecretProvider(p SecretProvider) {
+	job.secretProvider = p
+}
+
+// resolveSecretRef resolves value via the job's SecretProvider if it is a
+// secret reference, caching the result. A value that isn't a secret
+// reference is returned unchanged.
+func (job *Job) resolveSecretRef(value string) (string, error) {
+	if !strings.HasPrefix(value, secretRefPrefix) {
+		return value, nil
+	}
+	ref := value[len(secretRefPrefix):]
+
+	if job.secretProviderCache != nil {
+		if cached, ok := job.secretProviderCache[ref]; ok {
+			return cached, nil
+		}
+	}
+	if job.secretProvider == nil {
+		return "", fmt.Errorf("no secret provider configured to resolve %q", value)
+	}
+	resolved, err := job.secretProvider.Resolve(ref)
+	if err != nil {
+		return "", fmt.Errorf("resolving secret %q: %w", value, err)
+	}
+	if job.secretProviderCache == nil {
+		job.secretProviderCache = make(map[string]string)
+	}
+	job.secretProviderCache[ref] = resolved
+	return resolved, nil
+}
+
+// GetenvSecretStrict behaves like Getenv, but returns an error instead of
+// logging a warning and returning "" when a secret reference can't be
+// resolved.
+func (job *Job) GetenvSecretStrict(key string) (string, error) {
+	return job.resolveGetenv(key)
+}
@@ -0,0 +1,38 @@
+package engine // import "github.com/docker/docker/engine"
+
+import "testing"
+
+type fakeSecretProvider struct {
+	calls int
+}
+
+func (p *fakeSecretProvider) Resolve(ref string) (string, error) {
+	p.calls++
+	return "resolved-" + ref, nil
+}
+
+func TestSecretProviderResolvesAndCaches(t *testing.T) {
+	eng := New()
+	job := eng.Job("test")
+	provider := &fakeSecretProvider{}
+	job.SetSecretProvider(provider)
+	job.Setenv("DB_PASSWORD", "secret://db/password")
+
+	if got := job.Getenv("DB_PASSWORD"); got != "resolved-db/password" {
+		t.Fatalf("expected resolved value, got %q", got)
+	}
+	job.Getenv("DB_PASSWORD")
+	if provider.calls != 1 {
+		t.Fatalf("expected the provider to be called once due to caching, got %d", provider.calls)
+	}
+}
+
+func TestGetenvSecretStrictErrorsWithoutProvider(t *testing.T) {
+	eng := New()
+	job := eng.Job("test")
+	job.Setenv("DB_PASSWORD", "secret://db/password")
+
+	if _, err := job.GetenvSecretStrict("DB_PASSWORD"); err == nil {
+		t.Fatal("expected an error with no provider configured")
+	}
+}
@@ -0,0 +1,19 @@
+package engine // import "github.com/docker/docker/engine"
+
+// SetSecret stores value under key in a map kept separate from the job's
+// regular environment. Getenv consults it after the normal environment, so
+// handlers read secrets the same way as any other config, but every
+// serialization path (MarshalJSON, EncodeEnvCodec, Environ, ...) only ever
+// sees job.env and so never emits secret values.
+func (job *Job) SetSecret(key, value string) {
+	if job.secrets == nil {
+		job.secrets = make(map[string]string)
+	}
+	job.secrets[key] = value
+}
+
+// getSecret returns the secret stored under key, and whether one was set.
+func (job *Job) getSecret(key string) (string, bool) {
+	value, ok := job.secrets[key]
+	return value, ok
+}
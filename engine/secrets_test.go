@@ -0,0 +1,30 @@
+package engine // import "github.com/docker/docker/engine"
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSetSecretReadableButNotSerialized(t *testing.T) {
+	eng := New()
+	job := eng.Job("test")
+	job.SetSecret("API_KEY", "sekrit")
+	job.Setenv("NAME", "alice")
+
+	if got := job.Getenv("API_KEY"); got != "sekrit" {
+		t.Fatalf("expected secret to be readable via Getenv, got %q", got)
+	}
+
+	b, err := job.MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(b), "sekrit") || strings.Contains(string(b), "API_KEY") {
+		t.Fatalf("expected secret to be absent from MarshalJSON output, got %s", b)
+	}
+
+	env := job.Environ()
+	if _, ok := env["API_KEY"]; ok {
+		t.Fatal("expected secret to be absent from Environ")
+	}
+}
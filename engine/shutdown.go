@@ -0,0 +1,43 @@
+package engine // import "github.com/docker/docker/engine"
+
+// Subscribe returns a channel of engine-level Events, such as the final
+// "engine-shutdown" event sent by Shutdown. The channel is buffered;
+// events are dropped rather than blocking the publisher if a subscriber
+// falls behind.
+func (eng *Engine) Subscribe() <-chan Event {
+	ch := make(chan Event, 16)
+	eng.subMu.Lock()
+	eng.subscribers = append(eng.subscribers, ch)
+	eng.subMu.Unlock()
+	return ch
+}
+
+// publish delivers evt to every current subscriber, without blocking on a
+// subscriber that isn't keeping up.
+func (eng *Engine) publish(evt Event) {
+	eng.subMu.Lock()
+	defer eng.subMu.Unlock()
+	for _, ch := range eng.subscribers {
+		select {
+		case ch <- evt:
+		default:
+		}
+	}
+}
+
+// Shutdown waits for every job currently running on the engine to finish,
+// then publishes a final "engine-shutdown" event to every subscriber and
+// closes their channels, so a subscriber's receive loop exits instead of
+// leaking. Shutdown does not prevent new jobs from starting; callers that
+// need that should stop creating jobs before calling it.
+func (eng *Engine) Shutdown() {
+	eng.inFlight.Wait()
+	eng.publish(Event{Name: "engine-shutdown"})
+
+	eng.subMu.Lock()
+	defer eng.subMu.Unlock()
+	for _, ch := range eng.subscribers {
+		close(ch)
+	}
+	eng.subscribers = nil
+}
@@ -0,0 +1,22 @@
+package engine // import "github.com/docker/docker/engine"
+
+import "testing"
+
+func TestShutdownClosesSubscriberChannelAfterDraining(t *testing.T) {
+	eng := New()
+	eng.Register("noop", func(*Job) string { return StatusOK })
+	if err := eng.Job("noop").Run(); err != nil {
+		t.Fatal(err)
+	}
+
+	sub := eng.Subscribe()
+	eng.Shutdown()
+
+	last := Event{}
+	for evt := range sub {
+		last = evt
+	}
+	if last.Name != "engine-shutdown" {
+		t.Fatalf("expected a final engine-shutdown event, got %q", last.Name)
+	}
+}
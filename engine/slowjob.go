@@ -0,0 +1,32 @@
+package engine // import "github.com/docker/docker/engine"
+
+import "time"
+
+// slowJobWatcher pairs a duration threshold with the callback OnSlowJob
+// should fire once a job's run exceeds it.
+type slowJobWatcher struct {
+	threshold time.Duration
+	fn        func(job *Job, d time.Duration)
+}
+
+// OnSlowJob registers fn to be called, after any job on this Engine
+// finishes running, whenever that job's duration exceeded threshold. This
+// surfaces latency regressions without wiring metrics into every handler.
+func (eng *Engine) OnSlowJob(threshold time.Duration, fn func(job *Job, d time.Duration)) {
+	eng.slowJobMu.Lock()
+	defer eng.slowJobMu.Unlock()
+	eng.slowJobWatchers = append(eng.slowJobWatchers, slowJobWatcher{threshold: threshold, fn: fn})
+}
+
+// checkSlowJob fires any registered OnSlowJob callbacks whose threshold d
+// exceeds.
+func (eng *Engine) checkSlowJob(job *Job, d time.Duration) {
+	eng.slowJobMu.Lock()
+	watchers := eng.slowJobWatchers
+	eng.slowJobMu.Unlock()
+	for _, w := range watchers {
+		if d > w.threshold {
+			w.fn(job, d)
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package engine // import "github.com/docker/docker/engine"
+
+import (
+	"testing"
+	"time"
+)
+
+func TestOnSlowJobFiresPastThreshold(t *testing.T) {
+	eng := New()
+	eng.Register("slow", func(*Job) string {
+		time.Sleep(20 * time.Millisecond)
+		return StatusOK
+	})
+
+	var gotJob *Job
+	var gotDuration time.Duration
+	done := make(chan struct{})
+	eng.OnSlowJob(5*time.Millisecond, func(job *Job, d time.Duration) {
+		gotJob = job
+		gotDuration = d
+		close(done)
+	})
+
+	job := eng.Job("slow")
+	if err := job.Run(); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected OnSlowJob callback to fire")
+	}
+	if gotJob != job {
+		t.Fatalf("expected callback to receive the job, got %v", gotJob)
+	}
+	if gotDuration < 5*time.Millisecond {
+		t.Fatalf("expected duration past threshold, got %v", gotDuration)
+	}
+}
@@ -0,0 +1,46 @@
+package engine // import "github.com/docker/docker/engine"
+
+import "context"
+
+// spawnDepthKey is the context.Context key under which Spawn tracks how
+// many levels of spawning led to a given job.
+type spawnDepthKey struct{}
+
+// SetMaxSpawnDepth limits how many levels deep a chain of Spawn calls may
+// go before failing, to guard against a handler that spawns jobs that
+// spawn more, recursing without bound. A limit of 0, the default, allows
+// unlimited spawning.
+func (eng *Engine) SetMaxSpawnDepth(n int) {
+	eng.maxSpawnDepth = n
+}
+
+// Spawn creates a new job named name, tracking its depth in the chain of
+// Spawn calls that led to it. If the engine's SetMaxSpawnDepth limit is
+// exceeded, the returned job fails immediately with
+// StatusMaxSpawnDepthExceeded when run, instead of invoking the handler
+// that would have been registered for name.
+func (job *Job) Spawn(name string, args ...string) *Job {
+	depth := spawnDepth(job.spawnCtx) + 1
+
+	base := job.spawnCtx
+	if base == nil {
+		base = context.Background()
+	}
+	child := job.Eng.Job(name, args...)
+	child.spawnCtx = context.WithValue(base, spawnDepthKey{}, depth)
+
+	if job.Eng.maxSpawnDepth > 0 && depth > job.Eng.maxSpawnDepth {
+		child.handler = func(*Job) string { return StatusMaxSpawnDepthExceeded }
+	}
+	return child
+}
+
+// spawnDepth returns the spawn depth recorded in ctx, or 0 if ctx is nil or
+// carries none.
+func spawnDepth(ctx context.Context) int {
+	if ctx == nil {
+		return 0
+	}
+	depth, _ := ctx.Value(spawnDepthKey{}).(int)
+	return depth
+}
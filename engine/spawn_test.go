@@ -0,0 +1,24 @@
+package engine // import "github.com/docker/docker/engine"
+
+import "testing"
+
+func TestSpawnMaxDepth(t *testing.T) {
+	eng := New()
+	eng.SetMaxSpawnDepth(2)
+	eng.Register("recurse", func(*Job) string { return StatusOK })
+
+	root := eng.Job("recurse")
+	level1 := root.Spawn("recurse")
+	level2 := level1.Spawn("recurse")
+	level3 := level2.Spawn("recurse")
+
+	if err := level2.Run(); err != nil {
+		t.Fatalf("expected spawning within the limit to succeed: %v", err)
+	}
+	if err := level3.Run(); err == nil {
+		t.Fatal("expected spawning past the limit to fail")
+	}
+	if level3.Status() != StatusMaxSpawnDepthExceeded {
+		t.Fatalf("expected status %q, got %q", StatusMaxSpawnDepthExceeded, level3.Status())
+	}
+}
@@ -0,0 +1,35 @@
+package engine // import "github.com/docker/docker/engine"
+
+import "sync/atomic"
+
+// JobState describes the lifecycle phase of a Job.
+type JobState int32
+
+const (
+	// Created is the state of a Job that has not yet been run.
+	Created JobState = iota
+	// Running is the state of a Job whose handler is currently executing.
+	Running
+	// Finished is the state of a Job whose Run call has returned.
+	Finished
+)
+
+// String returns the name of the state, for logging.
+func (s JobState) String() string {
+	switch s {
+	case Created:
+		return "created"
+	case Running:
+		return "running"
+	case Finished:
+		return "finished"
+	default:
+		return "unknown"
+	}
+}
+
+// State returns the job's current lifecycle phase. It is safe to call
+// concurrently with Run.
+func (job *Job) State() JobState {
+	return JobState(atomic.LoadInt32(&job.state))
+}
@@ -0,0 +1,33 @@
+package engine // import "github.com/docker/docker/engine"
+
+import "testing"
+
+func TestJobStateTransitions(t *testing.T) {
+	eng := New()
+	started := make(chan struct{})
+	release := make(chan struct{})
+	if err := eng.Register("slow", func(job *Job) string {
+		close(started)
+		<-release
+		return StatusOK
+	}); err != nil {
+		t.Fatal(err)
+	}
+	job := eng.Job("slow")
+
+	if job.State() != Created {
+		t.Fatalf("expected Created before Run, got %s", job.State())
+	}
+
+	done := job.RunAsync()
+	<-started
+	if job.State() != Running {
+		t.Fatalf("expected Running during handler execution, got %s", job.State())
+	}
+	close(release)
+	<-done
+
+	if job.State() != Finished {
+		t.Fatalf("expected Finished after Run, got %s", job.State())
+	}
+}
@@ -0,0 +1,39 @@
+package engine // import "github.com/docker/docker/engine"
+
+import "bytes"
+
+// lineWriter splits writes on newlines, invoking fn once per complete
+// line. Any trailing partial line is delivered when Close is called.
+type lineWriter struct {
+	fn  func(line string)
+	buf []byte
+}
+
+func (w *lineWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i < 0 {
+			break
+		}
+		w.fn(string(w.buf[:i]))
+		w.buf = w.buf[i+1:]
+	}
+	return len(p), nil
+}
+
+func (w *lineWriter) Close() error {
+	if len(w.buf) > 0 {
+		w.fn(string(w.buf))
+		w.buf = nil
+	}
+	return nil
+}
+
+// SetStderrLogger installs a line-splitting writer as the job's Stderr that
+// invokes fn once per line written, instead of letting stderr mix with
+// Stdout or go to a file. This lets callers route handler diagnostics to a
+// structured logger while stdout keeps streaming to the client.
+func (job *Job) SetStderrLogger(fn func(line string)) {
+	job.Stderr = &lineWriter{fn: fn}
+}
@@ -0,0 +1,27 @@
+package engine // import "github.com/docker/docker/engine"
+
+import "testing"
+
+func TestSetStderrLogger(t *testing.T) {
+	eng := New()
+	var lines []string
+	job := eng.Job("test")
+	job.SetStderrLogger(func(line string) {
+		lines = append(lines, line)
+	})
+
+	job.Stderr.Write([]byte("one\ntwo\nthree"))
+	if c, ok := job.Stderr.(interface{ Close() error }); ok {
+		c.Close()
+	}
+
+	want := []string{"one", "two", "three"}
+	if len(lines) != len(want) {
+		t.Fatalf("expected %v, got %v", want, lines)
+	}
+	for i := range want {
+		if lines[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, lines)
+		}
+	}
+}
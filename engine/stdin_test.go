@@ -0,0 +1,57 @@
+package engine // import "github.com/docker/docker/engine"
+
+import (
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestRecordStdin(t *testing.T) {
+	eng := New()
+	if err := eng.Register("read-all", func(job *Job) string {
+		io.ReadAll(job.Stdin)
+		return StatusOK
+	}); err != nil {
+		t.Fatal(err)
+	}
+	job := eng.Job("read-all")
+	job.Stdin = strings.NewReader("hello world")
+	record := job.RecordStdin()
+
+	if err := job.Run(); err != nil {
+		t.Fatal(err)
+	}
+	if record.String() != "hello world" {
+		t.Fatalf("expected %q, got %q", "hello world", record.String())
+	}
+}
+
+func TestStdinScanner(t *testing.T) {
+	eng := New()
+	job := eng.Job("test")
+	job.Stdin = strings.NewReader("one\ntwo\nthree\n")
+
+	scanner := job.StdinScanner()
+	var lines []string
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	want := []string{"one", "two", "three"}
+	if len(lines) != len(want) {
+		t.Fatalf("expected %v, got %v", want, lines)
+	}
+	for i := range want {
+		if lines[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, lines)
+		}
+	}
+}
+
+func TestStdinScannerNil(t *testing.T) {
+	eng := New()
+	job := eng.Job("test")
+	scanner := job.StdinScanner()
+	if scanner.Scan() {
+		t.Fatal("expected no lines from a nil Stdin")
+	}
+}
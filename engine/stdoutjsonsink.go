@@ -0,0 +1,18 @@
+package engine // import "github.com/docker/docker/engine"
+
+import "encoding/json"
+
+// SetStdoutJSONSink installs a line-splitting writer as the job's Stdout
+// that decodes each line as a JSON object and passes it to fn as it
+// arrives, for live ingestion of NDJSON logs emitted by a handler. A line
+// that isn't valid JSON is passed through as {"message": line} rather than
+// dropped.
+func (job *Job) SetStdoutJSONSink(fn func(map[string]interface{})) {
+	job.Stdout = &lineWriter{fn: func(line string) {
+		var record map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			record = map[string]interface{}{"message": line}
+		}
+		fn(record)
+	}}
+}
@@ -0,0 +1,26 @@
+package engine // import "github.com/docker/docker/engine"
+
+import "testing"
+
+func TestSetStdoutJSONSinkMixedLines(t *testing.T) {
+	eng := New()
+	job := eng.Job("test")
+
+	var records []map[string]interface{}
+	job.SetStdoutJSONSink(func(r map[string]interface{}) {
+		records = append(records, r)
+	})
+
+	job.Stdout.Write([]byte(`{"level":"info","msg":"started"}` + "\n"))
+	job.Stdout.Write([]byte("plain text line\n"))
+
+	if len(records) != 2 {
+		t.Fatalf("expected 2 records, got %d", len(records))
+	}
+	if records[0]["msg"] != "started" {
+		t.Fatalf("expected decoded JSON record, got %#v", records[0])
+	}
+	if records[1]["message"] != "plain text line" {
+		t.Fatalf("expected plain line wrapped as message, got %#v", records[1])
+	}
+}
@@ -0,0 +1,18 @@
+package engine // import "github.com/docker/docker/engine"
+
+// StdoutLines installs a line-splitting writer as the job's Stdout and
+// returns a channel delivering each line as it's written, closed once the
+// job finishes (via OnExit). Writes block until the channel is drained, so
+// a slow consumer applies backpressure to the handler.
+func (job *Job) StdoutLines() <-chan string {
+	lines := make(chan string)
+	lw := &lineWriter{fn: func(line string) {
+		lines <- line
+	}}
+	job.Stdout = lw
+	job.OnExit(func() {
+		lw.Close()
+		close(lines)
+	})
+	return lines
+}
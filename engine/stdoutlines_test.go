@@ -0,0 +1,34 @@
+package engine // import "github.com/docker/docker/engine"
+
+import "testing"
+
+func TestStdoutLines(t *testing.T) {
+	eng := New()
+	eng.Register("lines", func(job *Job) string {
+		job.Stdout.Write([]byte("one\ntwo\nthree\n"))
+		return StatusOK
+	})
+	job := eng.Job("lines")
+	ch := job.StdoutLines()
+
+	var got []string
+	done := make(chan error, 1)
+	go func() { done <- job.Run() }()
+
+	for line := range ch {
+		got = append(got, line)
+	}
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"one", "two", "three"}
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, got)
+		}
+	}
+}
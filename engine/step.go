@@ -0,0 +1,28 @@
+package engine // import "github.com/docker/docker/engine"
+
+import (
+	"fmt"
+	"time"
+)
+
+// Step runs fn under its own deadline, logging the step's name and
+// duration. If fn does not return within d, Step returns a timeout error
+// without waiting further for fn (which may still be running in the
+// background). This structures long handlers into observable phases.
+func (job *Job) Step(name string, d time.Duration, fn func() error) error {
+	clock := job.getClock()
+	start := clock.Now()
+	done := make(chan error, 1)
+	go func() {
+		done <- fn()
+	}()
+
+	select {
+	case err := <-done:
+		job.Infof("step %s finished in %s\n", name, clock.Now().Sub(start))
+		return err
+	case <-clock.After(d):
+		job.Errorf("step %s timed out after %s\n", name, d)
+		return fmt.Errorf("step %s: timed out after %s", name, d)
+	}
+}
@@ -0,0 +1,23 @@
+package engine // import "github.com/docker/docker/engine"
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStep(t *testing.T) {
+	eng := New()
+	job := eng.Job("test")
+
+	if err := job.Step("fast", 100*time.Millisecond, func() error { return nil }); err != nil {
+		t.Fatalf("expected fast step to succeed, got %v", err)
+	}
+
+	err := job.Step("slow", 10*time.Millisecond, func() error {
+		time.Sleep(time.Second)
+		return nil
+	})
+	if err == nil {
+		t.Fatal("expected slow step to return a timeout error")
+	}
+}
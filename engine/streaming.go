@@ -0,0 +1,34 @@
+package engine // import "github.com/docker/docker/engine"
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// statusFramePrefix marks the final line RunStreaming writes to its
+// output, as the ASCII record separator (0x1E) is vanishingly unlikely to
+// appear in ordinary job output, letting a client tell it apart from the
+// preceding stdout.
+const statusFramePrefix = "\x1eSTATUS:"
+
+// RunStreaming runs the job with its Stdout set to w, so output reaches w
+// as it's produced, then writes a final framed status line once the
+// handler returns. This gives a client reading w a single stream
+// containing the job's output followed by a message it can parse with
+// ParseStatusFrame to learn the outcome, without a separate side channel.
+func (job *Job) RunStreaming(w io.Writer) error {
+	job.Stdout = w
+	err := job.Run()
+	fmt.Fprintf(w, "%s%s\n", statusFramePrefix, job.Status())
+	return err
+}
+
+// ParseStatusFrame reports whether line is a status frame written by
+// RunStreaming, and if so, the status it carries.
+func ParseStatusFrame(line string) (status string, ok bool) {
+	if !strings.HasPrefix(line, statusFramePrefix) {
+		return "", false
+	}
+	return strings.TrimSuffix(strings.TrimPrefix(line, statusFramePrefix), "\n"), true
+}
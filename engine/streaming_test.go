@@ -0,0 +1,38 @@
+package engine // import "github.com/docker/docker/engine"
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestRunStreaming(t *testing.T) {
+	eng := New()
+	eng.Register("greet", func(j *Job) string {
+		j.Stdout.Write([]byte("hello\n"))
+		return StatusOK
+	})
+	job := eng.Job("greet")
+
+	var buf bytes.Buffer
+	if err := job.RunStreaming(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	scanner := bufio.NewScanner(&buf)
+	var lines []string
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	if len(lines) != 2 || lines[0] != "hello" {
+		t.Fatalf("expected output followed by a status frame, got %v", lines)
+	}
+	status, ok := ParseStatusFrame(lines[1] + "\n")
+	if !ok || status != StatusOK {
+		t.Fatalf("expected a parseable status frame with %q, got %q (ok=%v)", StatusOK, status, ok)
+	}
+	if !strings.HasPrefix(lines[1], "\x1eSTATUS:") {
+		t.Fatalf("expected the status line to carry the frame prefix, got %q", lines[1])
+	}
+}
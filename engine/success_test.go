@@ -0,0 +1,21 @@
+package engine // import "github.com/docker/docker/engine"
+
+import "testing"
+
+func TestSetSuccessStatus(t *testing.T) {
+	eng := New()
+	eng.SetSuccessStatus("OK")
+	if err := eng.Register("custom", func(job *Job) string {
+		return "OK"
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	job := eng.Job("custom")
+	if err := job.Run(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !job.Succeeded() {
+		t.Fatal("expected job to be marked as succeeded")
+	}
+}
@@ -0,0 +1,22 @@
+package engine // import "github.com/docker/docker/engine"
+
+import "testing"
+
+func TestSetSuccessFuncCustomPredicate(t *testing.T) {
+	eng := New()
+	eng.Register("custom", func(*Job) string {
+		return "done"
+	})
+
+	job := eng.Job("custom")
+	job.SetSuccessFunc(func(status string) bool {
+		return status == "done"
+	})
+
+	if err := job.Run(); err != nil {
+		t.Fatalf("expected custom success predicate to report success, got %v", err)
+	}
+	if !job.Succeeded() {
+		t.Fatal("expected Succeeded to report true")
+	}
+}
@@ -0,0 +1,60 @@
+package engine // import "github.com/docker/docker/engine"
+
+import "io"
+
+// tailBuffer keeps the last n complete lines written to it, discarding
+// older lines as new ones arrive.
+type tailBuffer struct {
+	n     int
+	lines []string
+	buf   []byte
+}
+
+func (t *tailBuffer) Write(p []byte) (int, error) {
+	t.buf = append(t.buf, p...)
+	for {
+		i := indexNewline(t.buf)
+		if i < 0 {
+			break
+		}
+		t.push(string(t.buf[:i]))
+		t.buf = t.buf[i+1:]
+	}
+	return len(p), nil
+}
+
+func (t *tailBuffer) push(line string) {
+	t.lines = append(t.lines, line)
+	if len(t.lines) > t.n {
+		t.lines = t.lines[len(t.lines)-t.n:]
+	}
+}
+
+func indexNewline(b []byte) int {
+	for i, c := range b {
+		if c == '\n' {
+			return i
+		}
+	}
+	return -1
+}
+
+// SetTailBuffer installs a ring buffer of the last n lines written to
+// Stdout and Stderr, without removing a caller's own streams -- both keep
+// receiving every write; the tail buffer just also observes them. This
+// bounds the memory needed to report context around a failure without
+// retaining the job's entire output.
+func (job *Job) SetTailBuffer(n int) {
+	job.tail = &tailBuffer{n: n}
+	job.Stdout = io.MultiWriter(job.Stdout, job.tail)
+	job.Stderr = io.MultiWriter(job.Stderr, job.tail)
+}
+
+// Tail returns the lines currently held by the tail buffer installed via
+// SetTailBuffer, oldest first.
+func (job *Job) Tail() []string {
+	if job.tail == nil {
+		return nil
+	}
+	return job.tail.lines
+}
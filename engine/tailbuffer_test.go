@@ -0,0 +1,24 @@
+package engine // import "github.com/docker/docker/engine"
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestSetTailBuffer(t *testing.T) {
+	eng := New()
+	job := eng.Job("test")
+	job.SetTailBuffer(10)
+
+	for i := 0; i < 100; i++ {
+		fmt.Fprintf(job.Stdout, "line%d\n", i)
+	}
+
+	tail := job.Tail()
+	if len(tail) != 10 {
+		t.Fatalf("expected 10 lines, got %d", len(tail))
+	}
+	if tail[0] != "line90" || tail[9] != "line99" {
+		t.Fatalf("expected last 10 lines, got %v", tail)
+	}
+}
@@ -0,0 +1,28 @@
+package engine // import "github.com/docker/docker/engine"
+
+// Then returns a composite Job that runs the receiver and, only if it
+// succeeds, runs next -- propagating the receiver's failure status without
+// ever invoking next. On success, the receiver's environment is copied into
+// next before it runs, so simple pipelines can pass config forward without
+// the caller wiring it up by hand. This covers basic sequencing; use a
+// graph (RunGraph) for anything with more than two stages or branching.
+func (job *Job) Then(next *Job) *Job {
+	composite := &Job{
+		Eng:    job.Eng,
+		Name:   job.Name + "+" + next.Name,
+		Stdin:  job.Stdin,
+		Stdout: job.Stdout,
+		Stderr: job.Stderr,
+	}
+	composite.handler = func(*Job) string {
+		if err := job.Run(); err != nil {
+			return job.status
+		}
+		for key, value := range job.Environ() {
+			next.Setenv(key, value)
+		}
+		next.Run()
+		return next.status
+	}
+	return composite
+}
@@ -0,0 +1,45 @@
+package engine // import "github.com/docker/docker/engine"
+
+import "testing"
+
+func TestThenRunsSecondOnlyIfFirstSucceeds(t *testing.T) {
+	eng := New()
+	secondRan := false
+	eng.Register("fails", func(*Job) string { return StatusErr })
+	eng.Register("second", func(*Job) string {
+		secondRan = true
+		return StatusOK
+	})
+
+	composite := eng.Job("fails").Then(eng.Job("second"))
+	if err := composite.Run(); err == nil {
+		t.Fatal("expected composite to fail when the first job fails")
+	}
+	if secondRan {
+		t.Fatal("expected second job not to run after first job's failure")
+	}
+}
+
+func TestThenRunsBothOnSuccess(t *testing.T) {
+	eng := New()
+	secondRan := false
+	eng.Register("first", func(job *Job) string {
+		job.Setenv("FROM_FIRST", "yes")
+		return StatusOK
+	})
+	eng.Register("second", func(job *Job) string {
+		secondRan = true
+		if job.Getenv("FROM_FIRST") != "yes" {
+			return StatusErr
+		}
+		return StatusOK
+	})
+
+	composite := eng.Job("first").Then(eng.Job("second"))
+	if err := composite.Run(); err != nil {
+		t.Fatal(err)
+	}
+	if !secondRan {
+		t.Fatal("expected second job to run after first job's success")
+	}
+}
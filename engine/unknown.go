@@ -0,0 +1,39 @@
+package engine // import "github.com/docker/docker/engine"
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// UnknownEnvKeys reports the job's environment keys that don't correspond
+// to any field of dst, a struct (or pointer to struct) whose fields are
+// matched by an "env" tag, falling back to the upper-cased field name.
+// This helps catch typos in config keys that would otherwise be silently
+// dropped when the environment is decoded into dst (as RegisterTyped does).
+func (job *Job) UnknownEnvKeys(dst interface{}) []string {
+	known := make(map[string]bool)
+	t := reflect.TypeOf(dst)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t != nil && t.Kind() == reflect.Struct {
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			key := f.Tag.Get("env")
+			if key == "" {
+				key = strings.ToUpper(f.Name)
+			}
+			known[key] = true
+		}
+	}
+
+	var unknown []string
+	for key := range job.env.Map() {
+		if !known[key] {
+			unknown = append(unknown, key)
+		}
+	}
+	sort.Strings(unknown)
+	return unknown
+}
@@ -0,0 +1,23 @@
+package engine // import "github.com/docker/docker/engine"
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestUnknownEnvKeys(t *testing.T) {
+	type Config struct {
+		Name string `env:"NAME"`
+		Port int    `env:"PORT"`
+	}
+
+	eng := New()
+	job := eng.Job("test")
+	job.Setenv("NAME", "svc")
+	job.Setenv("TYPOED_KEY", "oops")
+
+	unknown := job.UnknownEnvKeys(&Config{})
+	if !reflect.DeepEqual(unknown, []string{"TYPOED_KEY"}) {
+		t.Fatalf("expected [TYPOED_KEY], got %#v", unknown)
+	}
+}
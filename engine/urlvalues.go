@@ -0,0 +1,19 @@
+package engine // import "github.com/docker/docker/engine"
+
+import "net/url"
+
+// EnvValues converts the job's environment to url.Values, suitable for
+// encoding as an HTML form or query string. A key holding a JSON-encoded
+// list (as produced by SetenvList) becomes a multi-valued entry; every
+// other key becomes single-valued.
+func (job *Job) EnvValues() url.Values {
+	values := make(url.Values)
+	for key, value := range job.env.Map() {
+		if list := job.env.GetList(key); list != nil {
+			values[key] = list
+			continue
+		}
+		values.Set(key, value)
+	}
+	return values
+}
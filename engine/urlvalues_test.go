@@ -0,0 +1,18 @@
+package engine // import "github.com/docker/docker/engine"
+
+import "testing"
+
+func TestEnvValuesMultiValued(t *testing.T) {
+	eng := New()
+	job := eng.Job("test")
+	job.SetenvList("TAGS", []string{"a", "b", "c"})
+	job.Setenv("NAME", "x")
+
+	values := job.EnvValues()
+	if got := values["TAGS"]; len(got) != 3 || got[0] != "a" || got[2] != "c" {
+		t.Fatalf("expected TAGS to be multi-valued [a b c], got %v", got)
+	}
+	if values.Get("NAME") != "x" {
+		t.Fatalf("expected NAME=x, got %q", values.Get("NAME"))
+	}
+}
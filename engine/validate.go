@@ -0,0 +1,73 @@
+package engine // import "github.com/docker/docker/engine"
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// EnvSpec describes one environment key expected by a job, for use with
+// ValidateEnv.
+type EnvSpec struct {
+	// Key is the environment variable name.
+	Key string
+	// Required marks the key as mandatory; ValidateEnv reports it missing
+	// if absent.
+	Required bool
+	// Kind, if non-empty, is the expected value type ("int" or "bool").
+	// An empty Kind means any string value is accepted.
+	Kind string
+}
+
+// FieldError describes why a single env key failed validation.
+type FieldError struct {
+	Field  string
+	Reason string
+}
+
+// ValidationError reports every FieldError encountered while validating a
+// job's environment against a set of EnvSpecs.
+type ValidationError struct {
+	Errors []FieldError
+}
+
+// Error implements the error interface, joining all field errors into one
+// message.
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, fe := range e.Errors {
+		msgs[i] = fmt.Sprintf("%s: %s", fe.Field, fe.Reason)
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// ValidateEnv checks the job's environment against specs, reporting every
+// key that is missing but required, or present with a value that doesn't
+// match its declared Kind. It returns nil if every spec is satisfied, or a
+// *ValidationError listing every failure otherwise.
+func (job *Job) ValidateEnv(specs ...EnvSpec) error {
+	var errs []FieldError
+	for _, spec := range specs {
+		if !job.env.Exists(job.namespaced(spec.Key)) {
+			if spec.Required {
+				errs = append(errs, FieldError{Field: spec.Key, Reason: "required but missing"})
+			}
+			continue
+		}
+		value := job.Getenv(spec.Key)
+		switch spec.Kind {
+		case "int":
+			if _, err := strconv.Atoi(value); err != nil {
+				errs = append(errs, FieldError{Field: spec.Key, Reason: "expected an integer"})
+			}
+		case "bool":
+			if _, err := strconv.ParseBool(value); err != nil {
+				errs = append(errs, FieldError{Field: spec.Key, Reason: "expected a boolean"})
+			}
+		}
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return &ValidationError{Errors: errs}
+}
@@ -0,0 +1,34 @@
+package engine // import "github.com/docker/docker/engine"
+
+import "testing"
+
+func TestValidateEnv(t *testing.T) {
+	eng := New()
+	job := eng.Job("test")
+	job.Setenv("PORT", "not-a-number")
+
+	err := job.ValidateEnv(
+		EnvSpec{Key: "NAME", Required: true},
+		EnvSpec{Key: "PORT", Required: true, Kind: "int"},
+	)
+	if err == nil {
+		t.Fatal("expected a validation error")
+	}
+	verr, ok := err.(*ValidationError)
+	if !ok {
+		t.Fatalf("expected *ValidationError, got %T", err)
+	}
+	if len(verr.Errors) != 2 {
+		t.Fatalf("expected 2 field errors, got %d: %v", len(verr.Errors), verr.Errors)
+	}
+}
+
+func TestValidateEnvOK(t *testing.T) {
+	eng := New()
+	job := eng.Job("test")
+	job.Setenv("PORT", "8080")
+
+	if err := job.ValidateEnv(EnvSpec{Key: "PORT", Required: true, Kind: "int"}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
@@ -0,0 +1,28 @@
+package engine // import "github.com/docker/docker/engine"
+
+// JobView exposes a read-only view of a Job, safe to pass to logging or
+// metrics code that should not be able to mutate it.
+type JobView interface {
+	Name() string
+	Args() []string
+	Getenv(key string) string
+	Status() string
+	String() string
+}
+
+// jobView implements JobView by delegating to the wrapped Job.
+type jobView struct {
+	job *Job
+}
+
+func (v jobView) Name() string             { return v.job.Name }
+func (v jobView) Args() []string           { return v.job.Args }
+func (v jobView) Getenv(key string) string { return v.job.Getenv(key) }
+func (v jobView) Status() string           { return v.job.Status() }
+func (v jobView) String() string           { return v.job.String() }
+
+// ReadOnly returns a JobView over job, exposing only its observable state
+// with no setters.
+func (job *Job) ReadOnly() JobView {
+	return jobView{job: job}
+}
@@ -0,0 +1,20 @@
+package engine // import "github.com/docker/docker/engine"
+
+import "testing"
+
+func TestReadOnlyView(t *testing.T) {
+	eng := New()
+	job := eng.Job("test", "a", "b")
+	job.Setenv("NAME", "value")
+
+	view := job.ReadOnly()
+	if view.Name() != "test" {
+		t.Fatalf("expected name test, got %q", view.Name())
+	}
+	if len(view.Args()) != 2 {
+		t.Fatalf("expected 2 args, got %v", view.Args())
+	}
+	if view.Getenv("NAME") != "value" {
+		t.Fatalf("expected env to reflect job state, got %q", view.Getenv("NAME"))
+	}
+}
@@ -0,0 +1,13 @@
+package engine // import "github.com/docker/docker/engine"
+
+// WithField attaches key=value as structured metadata that Debugf, Infof,
+// Warnf and Errorf include in every subsequent log line (as JSON) instead
+// of their plain formatted text. A later call with the same key overrides
+// the earlier value. It returns job, for chaining.
+func (job *Job) WithField(key string, value interface{}) *Job {
+	if job.fields == nil {
+		job.fields = make(map[string]interface{})
+	}
+	job.fields[key] = value
+	return job
+}
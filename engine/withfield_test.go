@@ -0,0 +1,27 @@
+package engine // import "github.com/docker/docker/engine"
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestWithFieldAppearsInJSONLog(t *testing.T) {
+	eng := New()
+	job := eng.Job("test")
+	var stderr bytes.Buffer
+	job.Stderr = &stderr
+
+	job.WithField("request_id", "abc123").Infof("handling request")
+
+	var line map[string]interface{}
+	if err := json.Unmarshal(stderr.Bytes(), &line); err != nil {
+		t.Fatalf("expected JSON log line, got %q: %v", stderr.String(), err)
+	}
+	if line["request_id"] != "abc123" {
+		t.Fatalf("expected request_id field, got %#v", line)
+	}
+	if line["msg"] != "handling request" {
+		t.Fatalf("expected msg field, got %#v", line)
+	}
+}